@@ -3,14 +3,17 @@ package main
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-lambda-go/lambdacontext"
 	"github.com/samber/lo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appotel"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appslog"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
 	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
@@ -56,8 +59,23 @@ const (
 	goalTypeRecentMonth goalType = "recent_month"
 )
 
+type command string
+
+const (
+	commandAnalyze command = "analyze"
+	commandDigest  command = "digest"
+)
+
 type payload struct {
+	// 未指定の場合は analyze を実行する
+	Command  command
 	GoalType goalType
+	// 未指定の場合は GOAL_POINTS の値を使う
+	TargetPoints int
+	// true の場合は当日のレポートが保存済みでも再実行する
+	Force bool
+	// digest の集計期間 (week または month)
+	Period string
 }
 
 func parseGoalType(typ goalType) model.GoalType {
@@ -75,17 +93,73 @@ func handleRequest(ctx context.Context, payload payload) (err error) {
 	}()
 
 	ctx = appctx.SetNow(ctx, time.Now())
+	// NOTE: Lambda の実行ではリクエスト ID を実行 ID とする
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		ctx = appctx.SetRunID(ctx, lc.AwsRequestID)
+	}
+
+	switch payload.Command {
+	case commandAnalyze, "":
+		return runAnalyze(ctx, payload)
+	case commandDigest:
+		return runDigest(ctx, payload)
+	default:
+		return fmt.Errorf("unknown command: %s", payload.Command)
+	}
+}
 
+func runAnalyze(ctx context.Context, payload payload) error {
 	analyze, err := registory.NewAnalyzeUsecase(ctx)
 	if err != nil {
 		return err
 	}
 	result := analyze(ctx, &usecase.AnalyzeInput{
-		Goal: parseGoalType(payload.GoalType),
+		Goal:         parseGoalType(payload.GoalType),
+		TargetPoints: lo.Ternary(payload.TargetPoints > 0, payload.TargetPoints, config.GoalPoints()),
+		Force:        payload.Force,
 	})
-	if result.IsError() {
-		return result.Error()
+	out, err := result.Get()
+	if err != nil {
+		return err
 	}
+	slog.Info("analyze completed",
+		slog.Bool("is_goal_achieved", out.IsGoalAchieved),
+		slog.Any("failed_persisters", out.FailedPersisters),
+		slog.String("lock_path", string(out.LockPath)),
+	)
+
+	return nil
+}
+
+// parseDigestPeriod はペイロードから集計期間を決める。
+// NOTE: Period 導入前のペイロードとの互換性のため、未指定の場合は GoalType と同じ長さの期間とする
+func parseDigestPeriod(payload payload) (model.DigestPeriod, error) {
+	if payload.Period == "" {
+		return lo.Ternary(parseGoalType(payload.GoalType) == model.GoalTypeRecentMonth, model.DigestPeriodMonth, model.DigestPeriodWeek), nil
+	}
+	return model.ParseDigestPeriod(payload.Period)
+}
+
+func runDigest(ctx context.Context, payload payload) error {
+	period, err := parseDigestPeriod(payload)
+	if err != nil {
+		return err
+	}
+	digest, err := registory.NewDigestUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	result := digest(ctx, &usecase.DigestInput{
+		Period: period,
+	})
+	out, err := result.Get()
+	if err != nil {
+		return err
+	}
+	slog.Info("digest completed",
+		slog.Int("entries", out.Entries),
+		slog.String("lock_path", string(out.LockPath)),
+	)
 
 	return nil
 }
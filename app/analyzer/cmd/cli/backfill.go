@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+func runBackfill(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("backfill", flag.ContinueOnError)
+	from := fs.String("from", "", "first date to reconstruct report (YYYY-MM-DD)")
+	to := fs.String("to", "", "last date to reconstruct report (YYYY-MM-DD), inclusive")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fromDate, err := time.ParseInLocation(time.DateOnly, *from, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid from date: %w", err)
+	}
+	toDate, err := time.ParseInLocation(time.DateOnly, *to, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid to date: %w", err)
+	}
+
+	backfill, err := registory.NewBackfillUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := backfill(ctx, &usecase.BackfillInput{
+		From:         fromDate,
+		To:           toDate,
+		Goal:         model.GoalTypeRecentWeek,
+		TargetPoints: config.GoalPoints(),
+	}).Get()
+	if err != nil {
+		return err
+	}
+
+	slog.Info("backfill completed",
+		slog.Int("days", out.Days),
+		slog.Int("skipped_days", out.SkippedDays),
+		slog.Any("incomplete_sources", out.IncompleteSources),
+	)
+	return nil
+}
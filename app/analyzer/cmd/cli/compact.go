@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+func runCompact(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("compact", flag.ContinueOnError)
+	from := fs.String("from", "", "first date of exported reports to compact (YYYY-MM-DD)")
+	to := fs.String("to", "", "last date of exported reports to compact (YYYY-MM-DD), inclusive")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	fromDate, err := time.ParseInLocation(time.DateOnly, *from, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid from date: %w", err)
+	}
+	toDate, err := time.ParseInLocation(time.DateOnly, *to, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid to date: %w", err)
+	}
+
+	compact, err := registory.NewCompactExportUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := compact(ctx, &usecase.CompactExportInput{
+		From: fromDate,
+		To:   toDate,
+	}).Get()
+	if err != nil {
+		return err
+	}
+
+	slog.Info("compact completed", slog.String("key", out.Key), slog.Int("rows", out.Rows))
+	return nil
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+func runDigest(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	period := fs.String("period", "week", "period to summarize (week: last 7 days, month: last 30 days)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	digestPeriod, err := model.ParseDigestPeriod(*period)
+	if err != nil {
+		return err
+	}
+
+	digest, err := registory.NewDigestUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	result := digest(ctx, &usecase.DigestInput{Period: digestPeriod})
+	out, err := result.Get()
+	if err != nil {
+		return err
+	}
+	slog.Info("digest completed",
+		slog.Int("entries", out.Entries),
+		slog.String("lock_path", string(out.LockPath)),
+	)
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+func runHistory(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("history", flag.ContinueOnError)
+	from := fs.String("from", "", "first date of reports to show (YYYY-MM-DD)")
+	to := fs.String("to", "", "last date of reports to show (YYYY-MM-DD), inclusive")
+	period := fs.String("period", "week", "goal period of reports (week or month)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	goalType, err := parsePeriod(*period)
+	if err != nil {
+		return err
+	}
+	fromDate, err := time.ParseInLocation(time.DateOnly, *from, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid from date: %w", err)
+	}
+	toDate, err := time.ParseInLocation(time.DateOnly, *to, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return fmt.Errorf("invalid to date: %w", err)
+	}
+
+	history, err := registory.NewHistoryUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := history(ctx, &usecase.HistoryInput{
+		Goal: goalType,
+		From: fromDate,
+		To:   toDate,
+	}).Get()
+	if err != nil {
+		return err
+	}
+
+	for _, report := range out.Reports {
+		fmt.Printf(
+			"%s\tachieved=%t\tpoints=%d/%d\tpaused=%t\n",
+			report.GeneratedAt.In(lo.ToPtr(date.LocationJST())).Format(time.DateOnly),
+			report.IsGoalAchieved,
+			report.EarnedPoints,
+			report.TargetPoints,
+			report.Paused,
+		)
+	}
+	return nil
+}
+
+// parsePeriod は目標期間の長さを表す文字列を目標の種類に変換する。
+func parsePeriod(period string) (model.GoalType, error) {
+	switch period {
+	case "week":
+		return model.GoalTypeRecentWeek, nil
+	case "month":
+		return model.GoalTypeRecentMonth, nil
+	default:
+		return 0, fmt.Errorf("unsupported period: %s", period)
+	}
+}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log/slog"
 	"os"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appotel"
 	"github.com/ss49919201/keeput/app/analyzer/internal/appslog"
@@ -28,7 +31,7 @@ func init() {
 	}
 }
 
-func run(ctx context.Context) (err error) {
+func run(ctx context.Context, args []string) (err error) {
 	defer func() {
 		if err != nil {
 			appotel.RecordSpanError(ctx, err)
@@ -36,6 +39,7 @@ func run(ctx context.Context) (err error) {
 	}()
 
 	ctx = appctx.SetNow(ctx, time.Now())
+	ctx = appctx.SetRunID(ctx, uuid.NewString())
 
 	shutdownTraceProvider, err := appotel.InitTraceProvider(ctx)
 	if err != nil {
@@ -63,22 +67,61 @@ func run(ctx context.Context) (err error) {
 	ctx, span := otel.Tracer(traceName).Start(ctx, "CLI Entrypoint")
 	defer span.End()
 
+	// NOTE: サブコマンドを省略した場合は analyze を実行する
+	if len(args) == 0 {
+		return runAnalyze(ctx, nil)
+	}
+	switch args[0] {
+	case "analyze":
+		return runAnalyze(ctx, args[1:])
+	case "pause":
+		return runPause(ctx, args[1:])
+	case "backfill":
+		return runBackfill(ctx, args[1:])
+	case "digest":
+		return runDigest(ctx, args[1:])
+	case "compact":
+		return runCompact(ctx, args[1:])
+	case "history":
+		return runHistory(ctx, args[1:])
+	case "maintain":
+		return runMaintain(ctx, args[1:])
+	default:
+		return fmt.Errorf("unknown command: %s", args[0])
+	}
+}
+
+func runAnalyze(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ContinueOnError)
+	force := fs.Bool("force", false, "analyze even if today's report already exists")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
 	analyze, err := registory.NewAnalyzeUsecase(ctx)
 	if err != nil {
 		return err
 	}
 	result := analyze(ctx, &usecase.AnalyzeInput{
-		Goal: model.GoalTypeRecentWeek,
+		Goal:         model.GoalTypeRecentWeek,
+		TargetPoints: config.GoalPoints(),
+		Force:        *force,
 	})
-	if result.IsError() {
-		return result.Error()
+	out, err := result.Get()
+	if err != nil {
+		return err
 	}
+	slog.Info("analyze completed",
+		slog.Bool("is_goal_achieved", out.IsGoalAchieved),
+		slog.Any("failed_persisters", out.FailedPersisters),
+		slog.String("lock_path", string(out.LockPath)),
+	)
 
 	return nil
 }
 
 func main() {
-	if err := run(context.Background()); err != nil {
+	if err := run(context.Background(), os.Args[1:]); err != nil {
 		slog.Error("failed to run cli program", slog.String("error", err.Error()))
 		os.Exit(1)
 	}
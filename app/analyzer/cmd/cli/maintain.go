@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+func runMaintain(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("maintain", flag.ContinueOnError)
+	retentionDays := fs.Int("retention-days", config.RetentionDays(), "days to keep reports including today (0 keeps reports forever)")
+	mode := fs.String("mode", config.RetentionMode(), "how to handle reports older than retention days (archive or delete)")
+	dryRun := fs.Bool("dry-run", false, "count target reports without changing them")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *mode != config.RetentionModeArchive && *mode != config.RetentionModeDelete {
+		return fmt.Errorf("invalid mode: %s", *mode)
+	}
+
+	maintain, err := registory.NewMaintainUsecase(ctx)
+	if err != nil {
+		return err
+	}
+	out, err := maintain(ctx, &usecase.MaintainInput{
+		RetentionDays: *retentionDays,
+		Archive:       *mode == config.RetentionModeArchive,
+		DryRun:        *dryRun,
+	}).Get()
+	if err != nil {
+		return err
+	}
+
+	slog.Info("maintain completed",
+		slog.Bool("dry_run", *dryRun),
+		slog.Int("compacted", out.Compacted),
+		slog.Int("monthly_files", out.MonthlyFiles),
+		slog.Int("deleted", out.Deleted),
+		slog.Int("archived", out.Archived),
+	)
+	return nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/ss49919201/keeput/app/analyzer/internal/registory"
+)
+
+const pauseUsage = "usage: cli pause add|remove -start YYYY-MM-DD -end YYYY-MM-DD [-reason REASON] | cli pause list"
+
+func runPause(ctx context.Context, args []string) error {
+	if len(args) == 0 {
+		return errors.New(pauseUsage)
+	}
+
+	switch args[0] {
+	case "add":
+		period, err := parsePausePeriodFlags("pause add", args[1:])
+		if err != nil {
+			return err
+		}
+		addPausePeriod, err := registory.NewAddPausePeriodUsecase(ctx)
+		if err != nil {
+			return err
+		}
+		return printPausePeriods(addPausePeriod(ctx, &usecase.AddPausePeriodInput{Period: period}).Get())
+	case "remove":
+		period, err := parsePausePeriodFlags("pause remove", args[1:])
+		if err != nil {
+			return err
+		}
+		removePausePeriod, err := registory.NewRemovePausePeriodUsecase(ctx)
+		if err != nil {
+			return err
+		}
+		return printPausePeriods(removePausePeriod(ctx, &usecase.RemovePausePeriodInput{Start: period.Start, End: period.End}).Get())
+	case "list":
+		listPausePeriods, err := registory.NewListPausePeriodsUsecase(ctx)
+		if err != nil {
+			return err
+		}
+		return printPausePeriods(listPausePeriods(ctx).Get())
+	default:
+		return errors.New(pauseUsage)
+	}
+}
+
+func parsePausePeriodFlags(name string, args []string) (model.PausePeriod, error) {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	start := fs.String("start", "", "start date of pause period (YYYY-MM-DD)")
+	end := fs.String("end", "", "end date of pause period (YYYY-MM-DD), inclusive")
+	reason := fs.String("reason", "", "reason for pause")
+	if err := fs.Parse(args); err != nil {
+		return model.PausePeriod{}, err
+	}
+	return model.NewPausePeriod(*start, *end, *reason)
+}
+
+func printPausePeriods(out *usecase.PausePeriodsOutput, err error) error {
+	if err != nil {
+		return err
+	}
+	for _, period := range out.Periods {
+		fmt.Printf(
+			"%s - %s\t%s\n",
+			period.Start.In(lo.ToPtr(date.LocationJST())).Format(time.DateOnly),
+			period.End.In(lo.ToPtr(date.LocationJST())).Format(time.DateOnly),
+			period.Reason,
+		)
+	}
+	return nil
+}
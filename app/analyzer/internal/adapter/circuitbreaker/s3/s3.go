@@ -0,0 +1,87 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"path"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/internal/s3client"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/circuitbreaker"
+)
+
+const (
+	keyPrefix = "circuit_breaker"
+)
+
+var s3ClientOnce sync.Once
+var s3Client *s3.Client
+
+func initS3Client(config aws.Config) {
+	s3ClientOnce.Do(func() {
+		s3Client = s3client.New(config)
+	})
+}
+
+func NewLoadState(config aws.Config) circuitbreaker.LoadState {
+	initS3Client(config)
+	return loadState
+}
+
+func NewSaveState(config aws.Config) circuitbreaker.SaveState {
+	initS3Client(config)
+	return saveState
+}
+
+func stateKey(source string) string {
+	return path.Join(keyPrefix, source+".json")
+}
+
+func loadState(ctx context.Context, source string) mo.Result[*model.CircuitBreakerState] {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(config.S3BucketName()),
+		Key:    aws.String(stateKey(source)),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return mo.Ok(model.NewCircuitBreakerState())
+		}
+		return mo.Err[*model.CircuitBreakerState](err)
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return mo.Err[*model.CircuitBreakerState](err)
+	}
+	var state model.CircuitBreakerState
+	if err := json.Unmarshal(b, &state); err != nil {
+		return mo.Err[*model.CircuitBreakerState](err)
+	}
+	return mo.Ok(&state)
+}
+
+func saveState(ctx context.Context, source string, state *model.CircuitBreakerState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(config.S3BucketName()),
+		Key:         aws.String(stateKey(source)),
+		Body:        bytes.NewReader(b),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
@@ -0,0 +1,53 @@
+package circuitbreaker
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/circuitbreaker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+// NewFetchEntries はフェッチ元ごとのタイムアウトとサーキットブレーカーで fetch を包む。
+func NewFetchEntries(source string, fetch fetcher.FetchEntries, loadState circuitbreaker.LoadState, saveState circuitbreaker.SaveState) fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return guard(ctx, source, fetch, loadState, saveState)
+	}
+}
+
+func guard[T any](ctx context.Context, source string, fetch func(context.Context) mo.Result[T], loadState circuitbreaker.LoadState, saveState circuitbreaker.SaveState) mo.Result[T] {
+	now := appctx.GetNowOr(ctx, time.Now())
+
+	state, err := loadState(ctx, source).Get()
+	if err != nil {
+		// NOTE: 状態を読み込めない場合はブレーカーを閉じているものとしてフェッチを継続する
+		slog.Warn("failed to load circuit breaker state", slog.String("source", source), slog.String("error", err.Error()))
+		state = model.NewCircuitBreakerState()
+	}
+	if state.IsOpen(now, config.CircuitBreakerCooldown()) {
+		return mo.Err[T](&fetcher.DegradedError{Source: source})
+	}
+
+	fetchCtx, cancel := context.WithTimeout(ctx, config.FetchTimeout(source))
+	defer cancel()
+	result := fetch(fetchCtx)
+
+	var next *model.CircuitBreakerState
+	if result.IsError() {
+		next = state.RecordFailure(now, config.CircuitBreakerFailureThreshold())
+	} else if state.ConsecutiveFailures > 0 || state.OpenedAt.IsPresent() {
+		next = state.RecordSuccess()
+	}
+	if next != nil {
+		if err := saveState(ctx, source, next); err != nil {
+			slog.Warn("failed to save circuit breaker state", slog.String("source", source), slog.String("error", err.Error()))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,160 @@
+package circuitbreaker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFetchEntries(t *testing.T) {
+	now := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	entries := []*model.Entry{{Title: "Go 言語の slice について"}}
+	succeed := func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return mo.Ok(entries)
+	}
+	fail := func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return mo.Err[[]*model.Entry](assert.AnError)
+	}
+
+	tests := []struct {
+		name string
+		// FETCH_TIMEOUT_<SOURCE> に設定する値
+		timeout   string
+		state     mo.Result[*model.CircuitBreakerState]
+		fetch     fetcher.FetchEntries
+		wantFetch bool
+		wantErr   error
+		// nil の場合は状態を保存しない
+		wantSaved *model.CircuitBreakerState
+	}{
+		{
+			name: "skip fetch while open",
+			state: mo.Ok(&model.CircuitBreakerState{
+				ConsecutiveFailures: 3,
+				OpenedAt:            mo.Some(now.Add(-time.Hour)),
+			}),
+			fetch:   succeed,
+			wantErr: &fetcher.DegradedError{Source: "zenn"},
+		},
+		{
+			name: "try once after cooldown",
+			state: mo.Ok(&model.CircuitBreakerState{
+				ConsecutiveFailures: 3,
+				OpenedAt:            mo.Some(now.Add(-7 * time.Hour)),
+			}),
+			fetch:     succeed,
+			wantFetch: true,
+			wantSaved: model.NewCircuitBreakerState(),
+		},
+		{
+			name:      "record failure",
+			state:     mo.Ok(model.NewCircuitBreakerState()),
+			fetch:     fail,
+			wantFetch: true,
+			wantErr:   assert.AnError,
+			wantSaved: &model.CircuitBreakerState{
+				ConsecutiveFailures: 1,
+				OpenedAt:            mo.None[time.Time](),
+			},
+		},
+		{
+			name: "open when failures reach threshold",
+			state: mo.Ok(&model.CircuitBreakerState{
+				ConsecutiveFailures: 2,
+				OpenedAt:            mo.None[time.Time](),
+			}),
+			fetch:     fail,
+			wantFetch: true,
+			wantErr:   assert.AnError,
+			wantSaved: &model.CircuitBreakerState{
+				ConsecutiveFailures: 3,
+				OpenedAt:            mo.Some(now),
+			},
+		},
+		{
+			name: "reset state on success",
+			state: mo.Ok(&model.CircuitBreakerState{
+				ConsecutiveFailures: 2,
+				OpenedAt:            mo.None[time.Time](),
+			}),
+			fetch:     succeed,
+			wantFetch: true,
+			wantSaved: model.NewCircuitBreakerState(),
+		},
+		{
+			name:      "do not save state when nothing changes",
+			state:     mo.Ok(model.NewCircuitBreakerState()),
+			fetch:     succeed,
+			wantFetch: true,
+		},
+		{
+			name:      "fetch when state cannot be loaded",
+			state:     mo.Err[*model.CircuitBreakerState](assert.AnError),
+			fetch:     succeed,
+			wantFetch: true,
+		},
+		{
+			name:    "cancel slow fetch after timeout",
+			timeout: "10ms",
+			state:   mo.Ok(model.NewCircuitBreakerState()),
+			fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+				select {
+				case <-ctx.Done():
+					return mo.Err[[]*model.Entry](ctx.Err())
+				case <-time.After(time.Second):
+					return mo.Ok(entries)
+				}
+			},
+			wantFetch: true,
+			wantErr:   context.DeadlineExceeded,
+			wantSaved: &model.CircuitBreakerState{
+				ConsecutiveFailures: 1,
+				OpenedAt:            mo.None[time.Time](),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.timeout != "" {
+				t.Setenv("FETCH_TIMEOUT_ZENN", tt.timeout)
+			}
+
+			fetched := false
+			var saved *model.CircuitBreakerState
+			fetch := NewFetchEntries(
+				"zenn",
+				func(ctx context.Context) mo.Result[[]*model.Entry] {
+					fetched = true
+					return tt.fetch(ctx)
+				},
+				func(ctx context.Context, source string) mo.Result[*model.CircuitBreakerState] {
+					assert.Equal(t, "zenn", source)
+					return tt.state
+				},
+				func(ctx context.Context, source string, state *model.CircuitBreakerState) error {
+					assert.Equal(t, "zenn", source)
+					saved = state
+					return nil
+				},
+			)
+
+			got, err := fetch(appctx.SetNow(context.Background(), now)).Get()
+			assert.Equal(t, tt.wantFetch, fetched)
+			assert.Equal(t, tt.wantSaved, saved)
+			// NOTE: フェッチのエラーはラップせずにそのまま返す
+			if tt.wantErr != nil {
+				assert.Equal(t, tt.wantErr, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, entries, got)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package feed
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+// NOTE: RSS/Atom/JSON Feed のいずれであるかは gofeed が内容から判別する
+func NewFetchEntries(source config.FeedSource) fetcher.FetchEntries {
+	platform := model.EntryPlatformFeed(source.DisplayName, source.Priority)
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return internal.FetchWithSummary(ctx, source.URL, platform)
+	}
+}
@@ -0,0 +1,65 @@
+package feed
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchEntries(t *testing.T) {
+	tests := []struct {
+		name        string
+		fixture     string
+		contentType string
+		wantBody    string
+	}{
+		{
+			"detect rss feed",
+			"testdata/rss.xml",
+			"application/rss+xml",
+			"Goにはgoroutineがあります",
+		},
+		{
+			"detect atom feed",
+			"testdata/atom.xml",
+			"application/atom+xml",
+			"Goにはgoroutineがあります",
+		},
+		{
+			"detect json feed",
+			"testdata/jsonfeed.json",
+			"application/feed+json",
+			"<p>Goにはgoroutineがあります</p>",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", tt.contentType)
+				http.ServeFile(w, r, tt.fixture)
+			}))
+			defer server.Close()
+
+			got := NewFetchEntries(config.FeedSource{
+				ID:          "tech-blog",
+				DisplayName: "Tech Blog",
+				URL:         server.URL,
+				Priority:    3,
+			})(context.Background())
+			require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+			require.Len(t, got.MustGet(), 2)
+
+			entry, ok := model.Latest(got.MustGet()).Get()
+			require.True(t, ok)
+			assert.Equal(t, "Goを学ぶ", entry.Title)
+			assert.Equal(t, tt.wantBody, entry.Body)
+			assert.Equal(t, model.EntryPlatformFeed("Tech Blog", 3), entry.Platform)
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package gitrepo
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+var markdownExts = []string{".md", ".markdown"}
+
+func NewFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return fetch(ctx, config.GitRepositoryDir(), config.GitArticlePaths())
+	}
+}
+
+// fetch は記事ファイルが公開状態で追加された、もしくは下書きから公開状態に変更されたコミットをエントリとして返す。
+// NOTE: 同じファイルが複数回公開されている場合は最初に公開したコミットのみを採用する。
+// 予約公開日時が未来の記事は除外し、過ぎている記事はコミット日時と予約公開日時の遅い方を公開日時とする。
+func fetch(ctx context.Context, repoDir string, articlePaths []string) mo.Result[[]*model.Entry] {
+	now := appctx.GetNowOr(ctx, time.Now())
+
+	repo, err := git.PlainOpenWithOptions(repoDir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return mo.Err[[]*model.Entry](err)
+	}
+	head, err := repo.Head()
+	if err != nil {
+		// NOTE: コミットが 1 つも存在しないリポジトリは記事なしとみなす
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return mo.Ok([]*model.Entry{})
+		}
+		return mo.Err[[]*model.Entry](err)
+	}
+	commits, err := repo.Log(&git.LogOptions{
+		From:  head.Hash(),
+		Order: git.LogOrderCommitterTime,
+	})
+	if err != nil {
+		return mo.Err[[]*model.Entry](err)
+	}
+	defer commits.Close()
+
+	entriesByPath := map[string]*model.Entry{}
+	err = commits.ForEach(func(commit *object.Commit) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		published, err := publishedArticles(ctx, commit, articlePaths)
+		if err != nil {
+			return err
+		}
+		for filePath, article := range published {
+			// NOTE: 予約公開の記事はコミット日時ではなく予約公開日時に公開されたものとする
+			publishedAt := commit.Committer.When
+			if scheduledAt, ok := article.Date.Get(); ok {
+				if scheduledAt.After(now) {
+					continue
+				}
+				if scheduledAt.After(publishedAt) {
+					publishedAt = scheduledAt
+				}
+			}
+			existing, ok := entriesByPath[filePath]
+			if ok && existing.PublishedAt.Before(publishedAt) {
+				continue
+			}
+			entriesByPath[filePath] = &model.Entry{
+				Title:       article.Title,
+				Body:        article.Body,
+				Tags:        article.Tags,
+				PublishedAt: publishedAt,
+				Platform:    model.EntryPlatformGitRepository(),
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return mo.Err[[]*model.Entry](err)
+	}
+
+	return mo.Ok(lo.Values(entriesByPath))
+}
+
+type publishedArticle struct {
+	*internal.FrontMatter
+	Body string
+}
+
+// publishedArticles は commit で公開された記事ファイルをパスごとに返す。
+// マージコミットは第一親との差分のみを見る。
+func publishedArticles(ctx context.Context, commit *object.Commit, articlePaths []string) (map[string]*publishedArticle, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+	var parentTree *object.Tree
+	if commit.NumParents() > 0 {
+		parent, err := commit.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	changes, err := object.DiffTreeWithOptions(ctx, parentTree, tree, object.DefaultDiffTreeOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	published := map[string]*publishedArticle{}
+	for _, change := range changes {
+		from, to, err := change.Files()
+		if err != nil {
+			return nil, err
+		}
+		// NOTE: File.Name はファイル名のみのため、リポジトリルートからのパスは ChangeEntry から取得する
+		if to == nil || !isArticle(change.To.Name, articlePaths) {
+			continue
+		}
+		article, err := parseArticle(change.To.Name, to)
+		if err != nil {
+			return nil, err
+		}
+		if article.Draft {
+			continue
+		}
+		if from != nil {
+			before, err := parseArticle(change.From.Name, from)
+			if err != nil {
+				return nil, err
+			}
+			if !before.Draft {
+				continue
+			}
+		}
+		published[change.To.Name] = article
+	}
+	return published, nil
+}
+
+func isArticle(filePath string, articlePaths []string) bool {
+	if !lo.Contains(markdownExts, strings.ToLower(path.Ext(filePath))) {
+		return false
+	}
+	return lo.SomeBy(articlePaths, func(articlePath string) bool {
+		return strings.HasPrefix(filePath, articlePath+"/")
+	})
+}
+
+func parseArticle(filePath string, file *object.File) (*publishedArticle, error) {
+	content, err := file.Contents()
+	if err != nil {
+		return nil, err
+	}
+	frontMatter, body, err := internal.ParseMarkdown(filePath, []byte(content))
+	if err != nil {
+		return nil, err
+	}
+	return &publishedArticle{
+		FrontMatter: frontMatter,
+		Body:        body,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package gitrepo
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testRepo struct {
+	t        *testing.T
+	dir      string
+	worktree *git.Worktree
+}
+
+func newTestRepo(t *testing.T) *testRepo {
+	t.Helper()
+	dir := t.TempDir()
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	worktree, err := repo.Worktree()
+	require.NoError(t, err)
+	return &testRepo{t: t, dir: dir, worktree: worktree}
+}
+
+func (r *testRepo) commit(when time.Time, files map[string]string) {
+	r.t.Helper()
+	for name, content := range files {
+		path := filepath.Join(r.dir, name)
+		require.NoError(r.t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(r.t, os.WriteFile(path, []byte(content), 0o644))
+		_, err := r.worktree.Add(name)
+		require.NoError(r.t, err)
+	}
+	signature := &object.Signature{Name: "keeput", Email: "keeput@example.com", When: when}
+	_, err := r.worktree.Commit("update", &git.CommitOptions{Author: signature, Committer: signature})
+	require.NoError(r.t, err)
+}
+
+func zennArticle(title string, published bool) string {
+	return "---\ntitle: \"" + title + "\"\ntopics: [\"go\"]\npublished: " + lo.Ternary(published, "true", "false") + "\n---\n本文です。\n"
+}
+
+func TestFetch(t *testing.T) {
+	repo := newTestRepo(t)
+	jan := func(day int) time.Time { return time.Date(2025, 1, day, 12, 0, 0, 0, time.UTC) }
+
+	repo.commit(jan(1), map[string]string{
+		"articles/published-on-add.md": zennArticle("追加時に公開", true),
+		"articles/draft.md":            zennArticle("下書きから公開", false),
+		"books/book/chapter.md":        zennArticle("対象外のパス", true),
+	})
+	repo.commit(jan(2), map[string]string{
+		"articles/published-on-add.md": zennArticle("追加時に公開(修正)", true),
+	})
+	repo.commit(jan(3), map[string]string{
+		"articles/draft.md": zennArticle("下書きから公開", true),
+	})
+	repo.commit(jan(4), map[string]string{
+		"articles/still-draft.md": zennArticle("下書きのまま", false),
+		"articles/scheduled.md":   "---\ntitle: 予約公開\npublished: true\npublished_at: 2025-02-01 09:00\n---\n",
+	})
+
+	ctx := appctx.SetNow(context.Background(), jan(10))
+	got := fetch(ctx, repo.dir, []string{"articles"})
+	require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+
+	entries := got.MustGet()
+	require.Len(t, entries, 2)
+	byTitle := lo.KeyBy(entries, func(e *model.Entry) string { return e.Title })
+
+	assert.True(t, jan(1).Equal(byTitle["追加時に公開"].PublishedAt))
+	assert.Equal(t, []string{"go"}, byTitle["追加時に公開"].Tags)
+	assert.Equal(t, model.EntryPlatformGitRepository(), byTitle["追加時に公開"].Platform)
+	assert.True(t, jan(3).Equal(byTitle["下書きから公開"].PublishedAt))
+}
+
+// NOTE: 予約公開日時を過ぎた記事はコミット日時ではなく予約公開日時に公開されたものとする
+func TestFetch_ScheduledDatePassed(t *testing.T) {
+	repo := newTestRepo(t)
+	jst := lo.ToPtr(date.LocationJST())
+
+	repo.commit(time.Date(2025, 1, 6, 12, 0, 0, 0, jst), map[string]string{
+		"articles/scheduled.md": "---\ntitle: 予約公開\npublished: true\npublished_at: 2025-01-09 09:00\n---\n",
+		"articles/backdated.md": "---\ntitle: 過去日時\npublished: true\npublished_at: 2025-01-01 09:00\n---\n",
+	})
+
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, jst))
+	got := fetch(ctx, repo.dir, []string{"articles"})
+	require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+
+	byTitle := lo.KeyBy(got.MustGet(), func(e *model.Entry) string { return e.Title })
+	require.Len(t, byTitle, 2)
+	assert.True(t, time.Date(2025, 1, 9, 9, 0, 0, 0, jst).Equal(byTitle["予約公開"].PublishedAt))
+	// NOTE: コミットより前の日時が指定されている場合はコミット日時とする
+	assert.True(t, time.Date(2025, 1, 6, 12, 0, 0, 0, jst).Equal(byTitle["過去日時"].PublishedAt))
+}
@@ -0,0 +1,222 @@
+package hatena
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/apphttp"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+const (
+	atomPubAuthBasic = "basic"
+	atomPubAuthWSSE  = "wsse"
+
+	// NOTE: next リンクが循環した場合に備えて取得するページ数に上限を設ける
+	maxAtomPubPages = 1000
+)
+
+var httpClient = sync.OnceValue(func() *http.Client {
+	return apphttp.DefaultClient()
+})
+
+type atomPubCredential struct {
+	hatenaID string
+	apiKey   string
+	auth     string
+}
+
+func atomPubEndpoint() string {
+	return fmt.Sprintf(
+		"https://blog.hatena.ne.jp/%s/%s/atom/entry",
+		url.PathEscape(config.HatenaID()),
+		url.PathEscape(config.HatenaBlogID()),
+	)
+}
+
+func atomPubCredentialFromConfig() atomPubCredential {
+	return atomPubCredential{
+		hatenaID: config.HatenaID(),
+		apiKey:   config.HatenaAPIKey(),
+		auth:     config.HatenaAtomPubAuth(),
+	}
+}
+
+// NewAtomPubFetchRecentEntries は AtomPub API から since 以降に公開されたエントリを取得する。
+// NOTE: コレクションは新しいエントリから順に返されるため、since より前のエントリを含むページに到達した時点で打ち切る。
+// 最新エントリを判別できるよう、since 以降のエントリが存在しない場合でも公開済みエントリが 1 件見つかるまでは取得を続ける。
+func NewAtomPubFetchRecentEntries(since func(now time.Time) time.Time) fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		threshold := since(appctx.GetNowOr(ctx, time.Now()))
+		return fetchEntriesAtomPub(ctx, atomPubEndpoint(), atomPubCredentialFromConfig(), func(entries []*model.Entry) bool {
+			return lo.SomeBy(entries, func(entry *model.Entry) bool {
+				return entry.PublishedAt.Before(threshold)
+			})
+		})
+	}
+}
+
+// NewAtomPubFetchEntries は AtomPub API から全ての公開済みエントリを取得する。
+func NewAtomPubFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return fetchEntriesAtomPub(ctx, atomPubEndpoint(), atomPubCredentialFromConfig(), func([]*model.Entry) bool {
+			return false
+		})
+	}
+}
+
+// fetchEntriesAtomPub は next リンクを辿ってエントリを取得する。
+// stop が true を返した時点で以降のページの取得を打ち切る。
+// NOTE: 下書きと公開日時が未来の予約投稿は除外する。
+func fetchEntriesAtomPub(ctx context.Context, endpoint string, credential atomPubCredential, stop func(entries []*model.Entry) bool) mo.Result[[]*model.Entry] {
+	now := appctx.GetNowOr(ctx, time.Now())
+
+	var entries []*model.Entry
+	visited := map[string]struct{}{}
+	next := endpoint
+	for range maxAtomPubPages {
+		if _, ok := visited[next]; ok || next == "" {
+			break
+		}
+		visited[next] = struct{}{}
+
+		feed, err := getAtomPubFeed(ctx, next, credential)
+		if err != nil {
+			return mo.Err[[]*model.Entry](err)
+		}
+		entries = append(entries, lo.FilterMap(feed.Entries, func(entry atomPubEntry, _ int) (*model.Entry, bool) {
+			if entry.isDraft() || entry.Published.IsZero() || entry.Published.After(now) {
+				return nil, false
+			}
+			return entry.toModel(), true
+		})...)
+		if stop(entries) {
+			break
+		}
+		next = feed.nextURL()
+	}
+	return mo.Ok(entries)
+}
+
+type atomPubFeed struct {
+	Links   []atomPubLink  `xml:"link"`
+	Entries []atomPubEntry `xml:"entry"`
+}
+
+type atomPubLink struct {
+	Rel  string `xml:"rel,attr"`
+	Href string `xml:"href,attr"`
+}
+
+func (f *atomPubFeed) nextURL() string {
+	link, ok := lo.Find(f.Links, func(link atomPubLink) bool {
+		return link.Rel == "next"
+	})
+	if !ok {
+		return ""
+	}
+	return link.Href
+}
+
+type atomPubEntry struct {
+	Title     string    `xml:"title"`
+	Published time.Time `xml:"published"`
+	Content   string    `xml:"content"`
+	// NOTE: content は記法のままのため、本文には HTML に変換済みの formatted-content を優先して使う
+	FormattedContent string            `xml:"http://www.hatena.ne.jp/info/xmlns# formatted-content"`
+	Categories       []atomPubCategory `xml:"category"`
+	Control          struct {
+		Draft string `xml:"http://www.w3.org/2007/app draft"`
+	} `xml:"http://www.w3.org/2007/app control"`
+}
+
+type atomPubCategory struct {
+	Term string `xml:"term,attr"`
+}
+
+// NOTE: 予約投稿は公開日時が未来の間は published で除外し、公開日時を過ぎたものは公開済みとして扱う
+func (e *atomPubEntry) isDraft() bool {
+	return e.Control.Draft == "yes"
+}
+
+func (e *atomPubEntry) toModel() *model.Entry {
+	return &model.Entry{
+		Title: e.Title,
+		Body:  lo.CoalesceOrEmpty(e.FormattedContent, e.Content),
+		Tags: lo.Map(e.Categories, func(category atomPubCategory, _ int) string {
+			return category.Term
+		}),
+		PublishedAt: e.Published,
+		Platform:    model.EntryPlatformHatena(),
+	}
+}
+
+func getAtomPubFeed(ctx context.Context, pageURL string, credential atomPubCredential) (*atomPubFeed, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := authorize(req, credential, time.Now()); err != nil {
+		return nil, err
+	}
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get atompub collection, unexpected status code %d, body %s", resp.StatusCode, body)
+	}
+
+	var feed atomPubFeed
+	if err := xml.NewDecoder(resp.Body).Decode(&feed); err != nil {
+		return nil, fmt.Errorf("failed to decode atompub collection: %w", err)
+	}
+	return &feed, nil
+}
+
+func authorize(req *http.Request, credential atomPubCredential, now time.Time) error {
+	switch credential.auth {
+	case atomPubAuthWSSE:
+		nonce := make([]byte, 16)
+		if _, err := rand.Read(nonce); err != nil {
+			return err
+		}
+		req.Header.Set("Authorization", `WSSE profile="UsernameToken"`)
+		req.Header.Set("X-WSSE", wsseHeader(credential.hatenaID, credential.apiKey, nonce, now))
+	case atomPubAuthBasic, "":
+		req.SetBasicAuth(credential.hatenaID, credential.apiKey)
+	default:
+		return fmt.Errorf("unsupported atompub auth: %s", credential.auth)
+	}
+	return nil
+}
+
+// PasswordDigest = Base64(SHA1(Nonce + Created + APIKey))
+func wsseHeader(username, apiKey string, nonce []byte, now time.Time) string {
+	created := now.UTC().Format(time.RFC3339)
+	digest := sha1.Sum(append(append(append([]byte{}, nonce...), created...), apiKey...))
+	return fmt.Sprintf(
+		`UsernameToken Username="%s", PasswordDigest="%s", Nonce="%s", Created="%s"`,
+		username,
+		base64.StdEncoding.EncodeToString(digest[:]),
+		base64.StdEncoding.EncodeToString(nonce),
+		created,
+	)
+}
@@ -0,0 +1,144 @@
+package hatena
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testHatenaID = "ss49919201"
+	testAPIKey   = "api-key"
+)
+
+var wssePattern = regexp.MustCompile(`^UsernameToken Username="(.+)", PasswordDigest="(.+)", Nonce="(.+)", Created="(.+)"$`)
+
+func verifyWSSE(header string) bool {
+	matches := wssePattern.FindStringSubmatch(header)
+	if matches == nil || matches[1] != testHatenaID {
+		return false
+	}
+	nonce, err := base64.StdEncoding.DecodeString(matches[3])
+	if err != nil {
+		return false
+	}
+	digest := sha1.Sum([]byte(string(nonce) + matches[4] + testAPIKey))
+	return base64.StdEncoding.EncodeToString(digest[:]) == matches[2]
+}
+
+// newAtomPubServer は記録済みの AtomPub レスポンスを page クエリに応じて返す
+func newAtomPubServer(t *testing.T, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+	pages := map[string]string{
+		"":           "testdata/atompub_page1.xml",
+		"1736000000": "testdata/atompub_page2.xml",
+		"1735000000": "testdata/atompub_page3.xml",
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		username, password, ok := r.BasicAuth()
+		authorized := (ok && username == testHatenaID && password == testAPIKey) || verifyWSSE(r.Header.Get("X-WSSE"))
+		if !authorized {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		fixture, ok := pages[r.URL.Query().Get("page")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		b, err := os.ReadFile(fixture)
+		require.NoError(t, err)
+		w.Header().Set("Content-Type", "application/atom+xml; charset=utf-8")
+		_, _ = w.Write([]byte(strings.ReplaceAll(string(b), "{{BASE_URL}}", server.URL)))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestFetchEntriesAtomPub(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, jst))
+
+	for _, auth := range []string{atomPubAuthBasic, atomPubAuthWSSE} {
+		t.Run("follow next links with "+auth+" auth", func(t *testing.T) {
+			var requests atomic.Int32
+			server := newAtomPubServer(t, &requests)
+
+			got := fetchEntriesAtomPub(
+				ctx,
+				server.URL+"/ss49919201/ss49919201.hatenablog.com/atom/entry",
+				atomPubCredential{hatenaID: testHatenaID, apiKey: testAPIKey, auth: auth},
+				func([]*model.Entry) bool { return false },
+			)
+			require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+
+			entries := got.MustGet()
+			require.Len(t, entries, 3)
+			// NOTE: 予約投稿でも公開日時を過ぎていれば対象とする
+			assert.Equal(t, "予約投稿で公開済みの記事", entries[0].Title)
+			assert.True(t, time.Date(2025, 1, 7, 9, 0, 0, 0, jst).Equal(entries[0].PublishedAt))
+			assert.Equal(t, "Rustを学ぶ", entries[1].Title)
+			assert.Equal(t, "<p>Rustには所有権という概念があります</p>", entries[1].Body)
+			assert.Equal(t, []string{"Rust", "プログラミング"}, entries[1].Tags)
+			assert.True(t, time.Date(2025, 1, 5, 12, 0, 0, 0, jst).Equal(entries[1].PublishedAt))
+			assert.Equal(t, model.EntryPlatformHatena(), entries[1].Platform)
+			assert.Equal(t, "Goを学ぶ", entries[2].Title)
+			assert.Equal(t, int32(3), requests.Load())
+		})
+	}
+
+	t.Run("return error when unauthorized", func(t *testing.T) {
+		var requests atomic.Int32
+		server := newAtomPubServer(t, &requests)
+
+		got := fetchEntriesAtomPub(
+			ctx,
+			server.URL+"/ss49919201/ss49919201.hatenablog.com/atom/entry",
+			atomPubCredential{hatenaID: testHatenaID, apiKey: "wrong", auth: atomPubAuthBasic},
+			func([]*model.Entry) bool { return false },
+		)
+		assert.True(t, got.IsError())
+	})
+}
+
+func TestFetchEntriesAtomPub_Stop(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	var requests atomic.Int32
+	server := newAtomPubServer(t, &requests)
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, jst))
+
+	got := fetchEntriesAtomPub(
+		ctx,
+		server.URL+"/ss49919201/ss49919201.hatenablog.com/atom/entry",
+		atomPubCredential{hatenaID: testHatenaID, apiKey: testAPIKey, auth: atomPubAuthBasic},
+		func(entries []*model.Entry) bool {
+			return lo.SomeBy(entries, func(entry *model.Entry) bool {
+				return entry.PublishedAt.Before(time.Date(2025, 1, 6, 0, 0, 0, 0, jst))
+			})
+		},
+	)
+	require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+	require.Len(t, got.MustGet(), 2)
+	assert.Equal(t, "Rustを学ぶ", got.MustGet()[1].Title)
+	// NOTE: 条件を満たしたページで打ち切られる
+	assert.Equal(t, int32(2), requests.Load())
+}
@@ -16,8 +16,24 @@ var httpClient = sync.OnceValue(func() *http.Client {
 	return apphttp.DefaultClient()
 })
 
+// Fetch はフィードのエントリを返す。本文にはエントリの内容を使う。
+func Fetch(ctx context.Context, feedURL string, entryPlatform model.EntryPlatform) mo.Result[[]*model.Entry] {
+	return fetch(ctx, feedURL, entryPlatform, func(item *gofeed.Item) string {
+		return item.Content
+	})
+}
+
+// FetchWithSummary はフィードのエントリを返す。
+// NOTE: 本文を持たず概要のみを配信するフィードもあるため、本文がない場合は概要を本文とみなす。
+// 文字数やポイントが変わらないよう、以前からのフェッチ元には使わない
+func FetchWithSummary(ctx context.Context, feedURL string, entryPlatform model.EntryPlatform) mo.Result[[]*model.Entry] {
+	return fetch(ctx, feedURL, entryPlatform, func(item *gofeed.Item) string {
+		return lo.CoalesceOrEmpty(item.Content, item.Description)
+	})
+}
+
 // NOTE: 公開日が存在しないエントリは除外する。
-func Fetch(ctx context.Context, feedURL string, entryPlatform model.EntryPlatform) (result mo.Result[[]*model.Entry]) {
+func fetch(ctx context.Context, feedURL string, entryPlatform model.EntryPlatform, body func(item *gofeed.Item) string) mo.Result[[]*model.Entry] {
 	fp := gofeed.NewParser()
 	fp.Client = httpClient()
 	feed, err := fp.ParseURLWithContext(feedURL, ctx)
@@ -35,7 +51,8 @@ func Fetch(ctx context.Context, feedURL string, entryPlatform model.EntryPlatfor
 
 				return &model.Entry{
 					Title:       item.Title,
-					Body:        item.Content,
+					Body:        body(item),
+					Tags:        item.Categories,
 					PublishedAt: *item.PublishedParsed,
 					Platform:    entryPlatform,
 				}, true
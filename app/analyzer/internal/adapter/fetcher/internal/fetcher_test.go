@@ -0,0 +1,70 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+  <channel>
+    <title>テスト</title>
+    <item>
+      <title>本文あり</title>
+      <description>概要</description>
+      <content:encoded>本文</content:encoded>
+      <pubDate>Thu, 09 Jan 2025 10:00:00 +0000</pubDate>
+    </item>
+    <item>
+      <title>概要のみ</title>
+      <description>概要</description>
+      <pubDate>Wed, 08 Jan 2025 10:00:00 +0000</pubDate>
+    </item>
+    <item>
+      <title>公開日なし</title>
+      <description>概要</description>
+    </item>
+  </channel>
+</rss>`
+
+func TestFetch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		_, _ = w.Write([]byte(testFeed))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name  string
+		fetch func(ctx context.Context, feedURL string, entryPlatform model.EntryPlatform) mo.Result[[]*model.Entry]
+		want  map[string]string
+	}{
+		{
+			name:  "use content as body",
+			fetch: Fetch,
+			want:  map[string]string{"本文あり": "本文", "概要のみ": ""},
+		},
+		{
+			name:  "use summary as body when content is empty",
+			fetch: FetchWithSummary,
+			want:  map[string]string{"本文あり": "本文", "概要のみ": "概要"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := tt.fetch(context.Background(), server.URL, model.EntryPlatformZenn()).Get()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, lo.SliceToMap(entries, func(entry *model.Entry) (string, string) {
+				return entry.Title, entry.Body
+			}))
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package internal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"gopkg.in/yaml.v3"
+)
+
+// FrontMatter は Hugo/Jekyll/Zenn の Markdown で共通して使われる項目のみを保持する。
+type FrontMatter struct {
+	Title string
+	Date  mo.Option[time.Time]
+	Draft bool
+	Tags  []string
+}
+
+var (
+	delimiterYAML = []byte("---")
+	delimiterTOML = []byte("+++")
+
+	// Jekyll の _posts は YYYY-MM-DD-title.md という命名規則を持つ
+	filenameDatePattern = regexp.MustCompile(`^(\d{4}-\d{2}-\d{2})-`)
+
+	dateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04:05",
+		"2006-01-02T15:04:05",
+		"2006-01-02 15:04",
+		time.DateOnly,
+	}
+)
+
+// ParseMarkdown は Markdown の先頭にある YAML(---) もしくは TOML(+++) のフロントマターを解析し、フロントマターと本文を返す。
+// NOTE: フロントマターを持たない場合は本文のみを返す
+func ParseMarkdown(filename string, content []byte) (*FrontMatter, string, error) {
+	raw, body, format := splitFrontMatter(content)
+
+	values := map[string]any{}
+	switch format {
+	case "yaml":
+		if err := yaml.Unmarshal(raw, &values); err != nil {
+			return nil, "", fmt.Errorf("failed to parse yaml front matter: %w", err)
+		}
+	case "toml":
+		if err := toml.Unmarshal(raw, &values); err != nil {
+			return nil, "", fmt.Errorf("failed to parse toml front matter: %w", err)
+		}
+	}
+
+	fm := &FrontMatter{
+		Title: stringValue(values["title"]),
+		// NOTE: Zenn は予約公開日時を published_at で指定する
+		Date:  dateValue(lo.CoalesceOrEmpty(values["date"], values["published_at"])),
+		Draft: boolValue(values["draft"]) || isUnpublished(values["published"]),
+		Tags:  lo.Uniq(append(stringsValue(values["tags"]), stringsValue(values["topics"])...)),
+	}
+	if fm.Date.IsAbsent() {
+		fm.Date = dateFromFilename(filename)
+	}
+	return fm, body, nil
+}
+
+func splitFrontMatter(content []byte) ([]byte, string, string) {
+	content = bytes.TrimPrefix(content, []byte("\ufeff"))
+	first, rest, ok := bytes.Cut(content, []byte("\n"))
+	if !ok {
+		return nil, string(content), ""
+	}
+	for _, delimiter := range []struct {
+		format string
+		value  []byte
+	}{
+		{"yaml", delimiterYAML},
+		{"toml", delimiterTOML},
+	} {
+		if !bytes.Equal(bytes.TrimSpace(first), delimiter.value) {
+			continue
+		}
+		raw, body, ok := cutLine(rest, delimiter.value)
+		if !ok {
+			continue
+		}
+		return raw, string(body), delimiter.format
+	}
+	return nil, string(content), ""
+}
+
+// delimiter のみからなる行で content を分割する
+func cutLine(content, delimiter []byte) ([]byte, []byte, bool) {
+	offset := 0
+	for offset <= len(content) {
+		line, _, _ := bytes.Cut(content[offset:], []byte("\n"))
+		if bytes.Equal(bytes.TrimSpace(line), delimiter) {
+			end := min(offset+len(line)+1, len(content))
+			return content[:offset], content[end:], true
+		}
+		if offset+len(line) >= len(content) {
+			break
+		}
+		offset += len(line) + 1
+	}
+	return nil, nil, false
+}
+
+func stringValue(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func boolValue(v any) bool {
+	b, _ := v.(bool)
+	return b
+}
+
+// Jekyll と Zenn は published: false で下書きを表す
+func isUnpublished(v any) bool {
+	b, ok := v.(bool)
+	return ok && !b
+}
+
+func stringsValue(v any) []string {
+	switch v := v.(type) {
+	case string:
+		// NOTE: Jekyll はスペース区切りの文字列でのタグ指定を許容する
+		return strings.Fields(v)
+	case []any:
+		return lo.FilterMap(v, func(item any, _ int) (string, bool) {
+			s, ok := item.(string)
+			return s, ok && s != ""
+		})
+	case []string:
+		return v
+	default:
+		return nil
+	}
+}
+
+func dateValue(v any) mo.Option[time.Time] {
+	switch v := v.(type) {
+	case time.Time:
+		// NOTE: TOML のローカル日時は専用のロケーションを持つため、JST の壁時計時刻として解釈し直す
+		if name := v.Location().String(); name == "datetime-local" || name == "date-local" {
+			return mo.Some(time.Date(v.Year(), v.Month(), v.Day(), v.Hour(), v.Minute(), v.Second(), v.Nanosecond(), lo.ToPtr(date.LocationJST())))
+		}
+		return mo.Some(v)
+	case string:
+		t, err := parseDate(v)
+		if err != nil {
+			return mo.None[time.Time]()
+		}
+		return mo.Some(t)
+	default:
+		return mo.None[time.Time]()
+	}
+}
+
+// NOTE: タイムゾーンを持たない日時は JST とみなす
+func parseDate(s string) (time.Time, error) {
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), lo.ToPtr(date.LocationJST())); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.New("unsupported date format: " + s)
+}
+
+func dateFromFilename(filename string) mo.Option[time.Time] {
+	matches := filenameDatePattern.FindStringSubmatch(filepath.Base(filename))
+	if matches == nil {
+		return mo.None[time.Time]()
+	}
+	t, err := parseDate(matches[1])
+	if err != nil {
+		return mo.None[time.Time]()
+	}
+	return mo.Some(t)
+}
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+var markdownExts = []string{".md", ".markdown"}
+
+func NewFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return fetch(ctx, config.MarkdownDir())
+	}
+}
+
+// NOTE: 下書き、公開日が存在しないもの、公開日が未来のものは除外する。
+// 隠しディレクトリと Jekyll の _drafts ディレクトリは走査しない。
+func fetch(ctx context.Context, dir string) mo.Result[[]*model.Entry] {
+	now := appctx.GetNowOr(ctx, time.Now())
+
+	var entries []*model.Entry
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "_drafts") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !lo.Contains(markdownExts, strings.ToLower(filepath.Ext(path))) {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// NOTE: フロントマターが不正なファイルがあってもフェッチ元全体を失敗させない
+		frontMatter, body, err := internal.ParseMarkdown(path, content)
+		if err != nil {
+			slog.Warn("skip markdown with invalid front matter", slog.String("path", path), slog.String("error", err.Error()))
+			return nil
+		}
+		publishedAt, ok := frontMatter.Date.Get()
+		if frontMatter.Draft || !ok || publishedAt.After(now) {
+			return nil
+		}
+
+		entries = append(entries, &model.Entry{
+			Title:       frontMatter.Title,
+			Body:        body,
+			Tags:        frontMatter.Tags,
+			PublishedAt: publishedAt,
+			Platform:    model.EntryPlatformMarkdown(),
+		})
+		return nil
+	})
+	if err != nil {
+		return mo.Err[[]*model.Entry](err)
+	}
+	return mo.Ok(entries)
+}
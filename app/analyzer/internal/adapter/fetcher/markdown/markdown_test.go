@@ -0,0 +1,121 @@
+package markdown
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestFetch(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "content", "posts", "hugo-yaml.md"), `---
+title: "Hugo の YAML フロントマター"
+date: 2025-01-05T12:00:00+09:00
+draft: false
+tags: ["go", "hugo"]
+---
+本文です。
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "hugo-toml.md"), `+++
+title = "Hugo の TOML フロントマター"
+date = 2025-01-06T12:00:00
+tags = ["rust"]
++++
+本文です。
+`)
+	writeFile(t, filepath.Join(dir, "_posts", "2025-01-07-jekyll.markdown"), `---
+title: Jekyll のファイル名から日付を取得する
+tags: jekyll ruby
+---
+本文です。
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "draft.md"), `---
+title: 下書き
+date: 2025-01-08T12:00:00+09:00
+draft: true
+---
+`)
+	writeFile(t, filepath.Join(dir, "_posts", "2025-01-08-unpublished.md"), `---
+title: 非公開
+published: false
+---
+`)
+	writeFile(t, filepath.Join(dir, "_drafts", "wip.md"), `---
+title: 作業中
+date: 2025-01-08 12:00:00 +0900
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "future.md"), `---
+title: 予約投稿
+date: 2025-01-20T12:00:00+09:00
+---
+`)
+	writeFile(t, filepath.Join(dir, "content", "posts", "no-date.md"), `---
+title: 日付なし
+---
+`)
+	writeFile(t, filepath.Join(dir, "README.txt"), "Markdown ではないファイル")
+
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, jst))
+	got := fetch(ctx, dir)
+	require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+
+	entries := got.MustGet()
+	require.Len(t, entries, 3)
+	byTitle := lo.KeyBy(entries, func(e *model.Entry) string { return e.Title })
+
+	assert.Equal(t, &model.Entry{
+		Title:       "Hugo の YAML フロントマター",
+		Body:        "本文です。\n",
+		Tags:        []string{"go", "hugo"},
+		PublishedAt: time.Date(2025, 1, 5, 12, 0, 0, 0, time.FixedZone("", 9*60*60)),
+		Platform:    model.EntryPlatformMarkdown(),
+	}, byTitle["Hugo の YAML フロントマター"])
+	assert.True(t, time.Date(2025, 1, 6, 12, 0, 0, 0, jst).Equal(byTitle["Hugo の TOML フロントマター"].PublishedAt))
+	assert.Equal(t, []string{"rust"}, byTitle["Hugo の TOML フロントマター"].Tags)
+	assert.True(t, time.Date(2025, 1, 7, 0, 0, 0, 0, jst).Equal(byTitle["Jekyll のファイル名から日付を取得する"].PublishedAt))
+	assert.Equal(t, []string{"jekyll", "ruby"}, byTitle["Jekyll のファイル名から日付を取得する"].Tags)
+}
+
+func TestFetch_InvalidFrontMatter(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	dir := t.TempDir()
+
+	writeFile(t, filepath.Join(dir, "valid.md"), `---
+title: 正しいフロントマター
+date: 2025-01-05T12:00:00+09:00
+---
+本文です。
+`)
+	writeFile(t, filepath.Join(dir, "broken.md"), `---
+title: [閉じていない配列
+date: 2025-01-06T12:00:00+09:00
+---
+本文です。
+`)
+
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, jst))
+	got := fetch(ctx, dir)
+	require.False(t, got.IsError(), "unexpected error: %v", got.Error())
+
+	entries := got.MustGet()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "正しいフロントマター", entries[0].Title)
+}
@@ -0,0 +1,25 @@
+package slide
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+)
+
+// NOTE: https://speakerdeck.com/{user}.atom を想定する
+func NewSpeakerDeckFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return internal.FetchWithSummary(ctx, config.FeedURLSpeakerDeck(), model.EntryPlatformSpeakerDeck(config.SlideWeight()))
+	}
+}
+
+// NOTE: https://www.docswell.com/user/{user}/feed を想定する
+func NewDocswellFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return internal.FetchWithSummary(ctx, config.FeedURLDocswell(), model.EntryPlatformDocswell(config.SlideWeight()))
+	}
+}
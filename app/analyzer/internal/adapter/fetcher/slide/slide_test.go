@@ -0,0 +1,95 @@
+package slide
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSlideWeight = 5
+
+// NOTE: 設定値は初回の参照時に固定されるため、フィードを配信するサーバーを起動してから環境変数を設定する
+func TestMain(m *testing.M) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /speakerdeck.atom", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/atom+xml")
+		http.ServeFile(w, r, "testdata/speakerdeck.atom")
+	})
+	mux.HandleFunc("GET /docswell.rss", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/rss+xml")
+		http.ServeFile(w, r, "testdata/docswell.rss")
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	for key, value := range map[string]string{
+		"FEED_URL_SPEAKERDECK": server.URL + "/speakerdeck.atom",
+		"FEED_URL_DOCSWELL":    server.URL + "/docswell.rss",
+		"SLIDE_WEIGHT":         strconv.Itoa(testSlideWeight),
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			panic(err)
+		}
+	}
+	os.Exit(m.Run())
+}
+
+func TestFetchEntries(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+
+	tests := []struct {
+		name         string
+		fetch        fetcher.FetchEntries
+		wantPlatform string
+		want         []*model.Entry
+	}{
+		{
+			name:         "speaker deck",
+			fetch:        NewSpeakerDeckFetchEntries(),
+			wantPlatform: "Speaker Deck",
+			want: []*model.Entry{
+				{Title: "Go の並行処理入門", Body: "goroutine と channel の基本を紹介します", PublishedAt: time.Date(2025, 1, 8, 19, 0, 0, 0, jst)},
+				{Title: "Rust の所有権", Body: "所有権と借用の考え方を紹介します", PublishedAt: time.Date(2024, 12, 10, 19, 0, 0, 0, jst)},
+			},
+		},
+		{
+			// NOTE: Docswell のフィードは本文を持たないため、概要を本文とみなす
+			name:         "docswell",
+			fetch:        NewDocswellFetchEntries(),
+			wantPlatform: "Docswell",
+			want: []*model.Entry{
+				{Title: "TypeScript の型パズル", Body: "条件型と infer の使い方を紹介します", PublishedAt: time.Date(2025, 1, 6, 10, 0, 0, 0, jst)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.fetch(context.Background()).Get()
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+
+			for i, entry := range got {
+				assert.Equal(t, tt.want[i].Title, entry.Title)
+				assert.Equal(t, tt.want[i].Body, entry.Body)
+				assert.True(t, tt.want[i].PublishedAt.Equal(entry.PublishedAt), "published at: %s", entry.PublishedAt)
+
+				assert.Equal(t, tt.wantPlatform, entry.Platform.Name)
+				assert.Equal(t, model.EntryKindTalk, entry.Platform.Type.Kind())
+				assert.Equal(t, testSlideWeight, entry.Platform.Weight)
+				// NOTE: 本文が短い登壇資料は SLIDE_WEIGHT のポイントのみを得る
+				assert.Equal(t, testSlideWeight, model.Points(entry))
+			}
+		})
+	}
+}
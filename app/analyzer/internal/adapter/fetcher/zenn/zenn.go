@@ -3,7 +3,6 @@ package zenn
 import (
 	"context"
 
-	"github.com/samber/lo"
 	"github.com/samber/mo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/internal"
 	"github.com/ss49919201/keeput/app/analyzer/internal/config"
@@ -11,24 +10,8 @@ import (
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
 )
 
-func NewFetchLatestEntry() fetcher.FetchLatestEntry {
-	return func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-		return fetchLatestEntry(ctx, config.FeedURLZenn())
+func NewFetchEntries() fetcher.FetchEntries {
+	return func(ctx context.Context) mo.Result[[]*model.Entry] {
+		return internal.Fetch(ctx, config.FeedURLZenn(), model.EntryPlatformZenn())
 	}
 }
-
-func fetchLatestEntry(ctx context.Context, feedURL string) mo.Result[mo.Option[*model.Entry]] {
-	entriesResult := internal.Fetch(ctx, feedURL, model.EntryPlatformZenn())
-	if entriesResult.IsError() {
-		return mo.Err[mo.Option[*model.Entry]](entriesResult.Error())
-	}
-
-	entries := entriesResult.MustGet()
-	latestEntry := lo.MaxBy(entries, func(a *model.Entry, b *model.Entry) bool {
-		return a.PublishedAt.After(b.PublishedAt)
-	})
-	if latestEntry == nil {
-		return mo.Ok(mo.None[*model.Entry]())
-	}
-	return mo.Ok(mo.Some(latestEntry))
-}
@@ -0,0 +1,20 @@
+package s3client
+
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+)
+
+// New は設定されたエンドポイント、パス形式、リージョンを適用した S3 クライアントを返す。
+func New(awsConfig aws.Config) *s3.Client {
+	return s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if endpoint := config.S3Endpoint(); endpoint != "" {
+			o.BaseEndpoint = aws.String(endpoint)
+		}
+		if region := config.S3Region(); region != "" {
+			o.Region = region
+		}
+		o.UsePathStyle = config.S3UsePathStyle()
+	})
+}
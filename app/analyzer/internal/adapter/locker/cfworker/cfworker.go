@@ -4,128 +4,132 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/samber/mo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/apphttp"
-	"github.com/ss49919201/keeput/app/analyzer/internal/config"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
 )
 
 const (
 	headerKeyLockerAPIKey = "X-LOCKER-API-KEY"
+
+	msgOK = "ok"
 )
 
 var httpClient = sync.OnceValue(func() *http.Client {
 	return apphttp.DefaultClient()
 })
 
-func NewAcquire() locker.Acquire {
-	return acquire
+// NewAcquire は baseURL にデプロイした Worker でロックを取得する。
+func NewAcquire(baseURL, apiKey string) locker.Acquire {
+	return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		return acquire(ctx, baseURL, apiKey, lockID, ttl)
+	}
+}
+
+func NewRenew(baseURL, apiKey string) locker.Renew {
+	return func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+		return renew(ctx, baseURL, apiKey, lease)
+	}
+}
+
+func NewRelease(baseURL, apiKey string) locker.Release {
+	return func(ctx context.Context, lease *locker.Lease) error {
+		return release(ctx, baseURL, apiKey, lease)
+	}
 }
 
-type acquireRequest struct {
+type lockRequest struct {
 	LockID string `json:"lockId"`
+	Token  string `json:"token"`
+	TTLMs  int64  `json:"ttlMs,omitempty"`
 }
 
-type acquireResponse struct {
+type lockResponse struct {
 	Msg string `json:"msg"`
 }
 
-func acquire(ctx context.Context, lockID string) mo.Result[bool] {
-	reqBody := acquireRequest{
-		LockID: lockID,
-	}
-	reqBodyBytes, err := json.Marshal(reqBody)
+func acquire(ctx context.Context, baseURL, apiKey, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+	lease := &locker.Lease{LockID: lockID, Token: uuid.NewString(), TTL: ttl}
+	msg, err := post(ctx, baseURL, apiKey, "/acquire", &lockRequest{
+		LockID: lease.LockID,
+		Token:  lease.Token,
+		TTLMs:  ttl.Milliseconds(),
+	})
 	if err != nil {
-		return mo.Err[bool](err)
+		return mo.Err[mo.Option[*locker.Lease]](fmt.Errorf("failed to acquire lock: %w", err))
 	}
-	url, err := url.JoinPath(config.LockerURLCloudflareWorker(), "/acquire")
-	if err != nil {
-		return mo.Err[bool](err)
-	}
-	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyBytes))
-	if err != nil {
-		return mo.Err[bool](err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set(headerKeyLockerAPIKey, config.LockerAPIKeyCloudflareWorker())
-	resp, err := httpClient().Do(req)
-	if err != nil {
-		return mo.Err[bool](err)
+	if msg != msgOK {
+		return mo.Ok(mo.None[*locker.Lease]())
 	}
-	defer resp.Body.Close()
-	if resp.StatusCode != 200 {
-		body, _ := io.ReadAll(resp.Body)
-		return mo.Err[bool](fmt.Errorf("failed to acquire lock, unexpected status code %d, body %s", resp.StatusCode, body))
-	}
-	respBodyBytes, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return mo.Err[bool](err)
-	}
-	var unmarshaledResp *acquireResponse
-	if err := json.Unmarshal(respBodyBytes, &unmarshaledResp); err != nil {
-		return mo.Err[bool](err)
-	}
-	return mo.Ok(unmarshaledResp.Msg == "ok")
+	return mo.Ok(mo.Some(lease))
 }
 
-func NewRelease() locker.Release {
-	return func(ctx context.Context, lockID string) error {
-		return release(ctx, lockID)
+func renew(ctx context.Context, baseURL, apiKey string, lease *locker.Lease) mo.Result[bool] {
+	msg, err := post(ctx, baseURL, apiKey, "/renew", &lockRequest{
+		LockID: lease.LockID,
+		Token:  lease.Token,
+		TTLMs:  lease.TTL.Milliseconds(),
+	})
+	if err != nil {
+		return mo.Err[bool](fmt.Errorf("failed to renew lock: %w", err))
 	}
+	return mo.Ok(msg == msgOK)
 }
 
-type releaseRequest struct {
-	LockID string `json:"lockId"`
-}
-
-type releaseResponse struct {
-	Msg string `json:"msg"`
+// release は所有者のロックを解放する。Worker は所有者でない場合も ok を返す。
+func release(ctx context.Context, baseURL, apiKey string, lease *locker.Lease) error {
+	msg, err := post(ctx, baseURL, apiKey, "/release", &lockRequest{
+		LockID: lease.LockID,
+		Token:  lease.Token,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release lock: %w", err)
+	}
+	if msg != msgOK {
+		return fmt.Errorf("failed to release lock, unexpected msg %s", msg)
+	}
+	return nil
 }
 
-func release(ctx context.Context, lockID string) error {
-	reqBody := releaseRequest{
-		LockID: lockID,
-	}
+func post(ctx context.Context, baseURL, apiKey, path string, reqBody *lockRequest) (string, error) {
 	reqBodyBytes, err := json.Marshal(reqBody)
 	if err != nil {
-		return err
+		return "", err
 	}
-	url, err := url.JoinPath(config.LockerURLCloudflareWorker(), "/release")
+	url, err := url.JoinPath(baseURL, path)
 	if err != nil {
-		return err
+		return "", err
 	}
 	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(reqBodyBytes))
 	if err != nil {
-		return err
+		return "", err
 	}
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set(headerKeyLockerAPIKey, config.LockerAPIKeyCloudflareWorker())
+	req.Header.Set(headerKeyLockerAPIKey, apiKey)
 	resp, err := httpClient().Do(req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != 200 {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to release lock, unexpected status code %d, body %s", resp.StatusCode, body)
+		return "", fmt.Errorf("unexpected status code %d, body %s", resp.StatusCode, body)
 	}
 	respBodyBytes, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return err
+		return "", err
 	}
-	var unmarshaledResp *releaseResponse
+	var unmarshaledResp *lockResponse
 	if err := json.Unmarshal(respBodyBytes, &unmarshaledResp); err != nil {
-		return err
+		return "", err
 	}
-	if unmarshaledResp.Msg != "ok" {
-		return errors.New("failed release lock")
-	}
-	return nil
+	return unmarshaledResp.Msg, nil
 }
@@ -0,0 +1,116 @@
+package cfworker
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/internal/lockertest"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAPIKey = "test-api-key"
+
+type fakeLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+// newFakeWorker は app/locker の Worker と同じ API を提供するサーバーを起動する。
+// NOTE: ロックの判定は Worker の Durable Object (app/locker/src/locker.ts) を Go で再現したものであり、
+// このパッケージのテストはリクエストの組み立てとレスポンスの解釈を確認する HTTP クライアントのテストである。
+// Durable Object 自体の振る舞いは検証しないため、locker.ts を変更した場合はこのフェイクも合わせて変更し、wrangler dev で確認する
+func newFakeWorker(t *testing.T, now func() time.Time) *httptest.Server {
+	var mu sync.Mutex
+	locks := map[string]fakeLock{}
+
+	handle := func(fn func(req *lockRequest) bool) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get(headerKeyLockerAPIKey) != testAPIKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var req lockRequest
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.LockID == "" || req.Token == "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			ok := fn(&req)
+			mu.Unlock()
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]string{"msg": map[bool]string{true: "ok", false: "ng"}[ok]})
+		}
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /acquire", handle(func(req *lockRequest) bool {
+		if lock, ok := locks[req.LockID]; ok && now().Before(lock.expiresAt) {
+			return false
+		}
+		locks[req.LockID] = fakeLock{token: req.Token, expiresAt: now().Add(time.Duration(req.TTLMs) * time.Millisecond)}
+		return true
+	}))
+	mux.HandleFunc("POST /renew", handle(func(req *lockRequest) bool {
+		lock, ok := locks[req.LockID]
+		if !ok || lock.token != req.Token || !now().Before(lock.expiresAt) {
+			return false
+		}
+		locks[req.LockID] = fakeLock{token: req.Token, expiresAt: now().Add(time.Duration(req.TTLMs) * time.Millisecond)}
+		return true
+	}))
+	mux.HandleFunc("POST /release", handle(func(req *lockRequest) bool {
+		if lock, ok := locks[req.LockID]; ok && lock.token == req.Token {
+			delete(locks, req.LockID)
+		}
+		return true
+	}))
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newLocker(baseURL, apiKey string) locker.Locker {
+	return locker.Locker{
+		Acquire: NewAcquire(baseURL, apiKey),
+		Renew:   NewRenew(baseURL, apiKey),
+		Release: NewRelease(baseURL, apiKey),
+	}
+}
+
+// NOTE: フェイクに対して共通のテストを実行し、クライアントが Worker の API を正しく扱えることを確認する
+func TestLocker(t *testing.T) {
+	lockertest.Run(t, func(t *testing.T) locker.Locker {
+		return newLocker(newFakeWorker(t, time.Now).URL, testAPIKey)
+	})
+}
+
+func TestLocker_Expiry(t *testing.T) {
+	lockertest.RunExpiry(t, func(t *testing.T) (locker.Locker, lockertest.Advance) {
+		now := time.Now()
+		lock := newLocker(newFakeWorker(t, func() time.Time { return now }).URL, testAPIKey)
+		return lock, func(d time.Duration) {
+			now = now.Add(d)
+		}
+	})
+}
+
+func TestLocker_Unauthorized(t *testing.T) {
+	lock := newLocker(newFakeWorker(t, time.Now).URL, "invalid")
+
+	got := lock.Acquire(context.Background(), "usecase:analyze:2025-01-01", time.Minute)
+	require.True(t, got.IsError())
+	assert.Contains(t, got.Error().Error(), "401")
+
+	err := lock.Release(context.Background(), &locker.Lease{LockID: "usecase:analyze:2025-01-01", Token: "token"})
+	assert.ErrorContains(t, err, "401")
+}
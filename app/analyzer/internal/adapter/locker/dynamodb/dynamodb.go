@@ -0,0 +1,144 @@
+package dynamodb
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+const (
+	attributeLockID    = "lock_id"
+	attributeOwner     = "owner"
+	attributeExpiresAt = "expires_at"
+)
+
+// Locks はテーブルの lock_id をパーティションキーとする項目を条件付きで書き込み、ロックを取得する。
+// NOTE: テーブルの TTL 属性には expires_at を設定する。
+// TTL による削除は遅れることがあるため、期限切れの項目が残っていても取得できるようにする
+type Locks struct {
+	client *dynamodb.Client
+	table  string
+	now    func() time.Time
+}
+
+func NewLocks(config aws.Config, table string) *Locks {
+	return &Locks{
+		client: dynamodb.NewFromConfig(config),
+		table:  table,
+		now:    time.Now,
+	}
+}
+
+func NewAcquire(locks *Locks) locker.Acquire {
+	return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		return acquire(ctx, locks, lockID, ttl)
+	}
+}
+
+func NewRenew(locks *Locks) locker.Renew {
+	return func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+		return renew(ctx, locks, lease)
+	}
+}
+
+func NewRelease(locks *Locks) locker.Release {
+	return func(ctx context.Context, lease *locker.Lease) error {
+		return release(ctx, locks, lease)
+	}
+}
+
+func acquire(ctx context.Context, locks *Locks, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+	now := locks.now()
+	lease := &locker.Lease{LockID: lockID, Token: uuid.NewString(), TTL: ttl}
+	_, err := locks.client.PutItem(ctx, &dynamodb.PutItemInput{
+		TableName: aws.String(locks.table),
+		Item: map[string]types.AttributeValue{
+			attributeLockID:    &types.AttributeValueMemberS{Value: lockID},
+			attributeOwner:     &types.AttributeValueMemberS{Value: lease.Token},
+			attributeExpiresAt: unixTime(now.Add(ttl)),
+		},
+		ConditionExpression: aws.String("attribute_not_exists(#lock_id) OR #expires_at < :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#lock_id":    attributeLockID,
+			"#expires_at": attributeExpiresAt,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":now": unixTime(now),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return mo.Ok(mo.None[*locker.Lease]())
+	}
+	if err != nil {
+		return mo.Err[mo.Option[*locker.Lease]](err)
+	}
+	return mo.Ok(mo.Some(lease))
+}
+
+// renew は所有者のロックが期限切れでない場合のみ期限を延長する。
+func renew(ctx context.Context, locks *Locks, lease *locker.Lease) mo.Result[bool] {
+	now := locks.now()
+	_, err := locks.client.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName: aws.String(locks.table),
+		Key: map[string]types.AttributeValue{
+			attributeLockID: &types.AttributeValueMemberS{Value: lease.LockID},
+		},
+		UpdateExpression:    aws.String("SET #expires_at = :expires_at"),
+		ConditionExpression: aws.String("#owner = :owner AND #expires_at >= :now"),
+		ExpressionAttributeNames: map[string]string{
+			"#owner":      attributeOwner,
+			"#expires_at": attributeExpiresAt,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":owner":      &types.AttributeValueMemberS{Value: lease.Token},
+			":now":        unixTime(now),
+			":expires_at": unixTime(now.Add(lease.TTL)),
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return mo.Ok(false)
+	}
+	if err != nil {
+		return mo.Err[bool](err)
+	}
+	return mo.Ok(true)
+}
+
+// release は所有者のロックを解放する。他の所有者が取得している場合は何もしない。
+func release(ctx context.Context, locks *Locks, lease *locker.Lease) error {
+	_, err := locks.client.DeleteItem(ctx, &dynamodb.DeleteItemInput{
+		TableName: aws.String(locks.table),
+		Key: map[string]types.AttributeValue{
+			attributeLockID: &types.AttributeValueMemberS{Value: lease.LockID},
+		},
+		ConditionExpression: aws.String("#owner = :owner"),
+		ExpressionAttributeNames: map[string]string{
+			"#owner": attributeOwner,
+		},
+		ExpressionAttributeValues: map[string]types.AttributeValue{
+			":owner": &types.AttributeValueMemberS{Value: lease.Token},
+		},
+	})
+	if isConditionalCheckFailed(err) {
+		return nil
+	}
+	return err
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
+
+// NOTE: TTL 属性はエポック秒の数値である必要がある
+func unixTime(t time.Time) types.AttributeValue {
+	return &types.AttributeValueMemberN{Value: strconv.FormatInt(t.Unix(), 10)}
+}
@@ -0,0 +1,174 @@
+package dynamodb
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/google/uuid"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/internal/lockertest"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeItem struct {
+	owner     string
+	expiresAt int64
+}
+
+// newFakeDynamoDB は Locks が使う操作のみを実装した DynamoDB 互換のサーバーを起動する。
+// NOTE: 条件式は Locks が送るものと文字列で照合して解釈し、それ以外は ValidationException を返す。
+// 条件式が DynamoDB で意図どおりに評価されるかは検証できないため、Docker なしで実行するためのスモークテストとして扱う。
+// 条件式の検証は CI で DynamoDB Local に対して実行する (make test-dynamodb)
+func newFakeDynamoDB(t *testing.T) string {
+	var mu sync.Mutex
+	items := map[string]fakeItem{}
+
+	type attributeValue struct {
+		S string `json:"S,omitempty"`
+		N string `json:"N,omitempty"`
+	}
+	type request struct {
+		Item                      map[string]attributeValue `json:"Item"`
+		Key                       map[string]attributeValue `json:"Key"`
+		ConditionExpression       string                    `json:"ConditionExpression"`
+		ExpressionAttributeValues map[string]attributeValue `json:"ExpressionAttributeValues"`
+	}
+
+	writeError := func(w http.ResponseWriter, errorType, message string) {
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		w.WriteHeader(http.StatusBadRequest)
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"__type":  "com.amazonaws.dynamodb.v20120810#" + errorType,
+			"message": message,
+		})
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req request
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, "SerializationException", err.Error())
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "DynamoDB_20120810.") {
+		case "CreateTable":
+		case "PutItem":
+			if req.ConditionExpression != "attribute_not_exists(#lock_id) OR #expires_at < :now" {
+				writeError(w, "ValidationException", "unsupported condition expression: "+req.ConditionExpression)
+				return
+			}
+			now, _ := strconv.ParseInt(req.ExpressionAttributeValues[":now"].N, 10, 64)
+			lockID := req.Item[attributeLockID].S
+			if item, ok := items[lockID]; ok && item.expiresAt >= now {
+				writeError(w, "ConditionalCheckFailedException", "The conditional request failed")
+				return
+			}
+			expiresAt, _ := strconv.ParseInt(req.Item[attributeExpiresAt].N, 10, 64)
+			items[lockID] = fakeItem{owner: req.Item[attributeOwner].S, expiresAt: expiresAt}
+		case "DeleteItem":
+			if req.ConditionExpression != "#owner = :owner" {
+				writeError(w, "ValidationException", "unsupported condition expression: "+req.ConditionExpression)
+				return
+			}
+			lockID := req.Key[attributeLockID].S
+			if item, ok := items[lockID]; !ok || item.owner != req.ExpressionAttributeValues[":owner"].S {
+				writeError(w, "ConditionalCheckFailedException", "The conditional request failed")
+				return
+			}
+			delete(items, lockID)
+		case "UpdateItem":
+			if req.ConditionExpression != "#owner = :owner AND #expires_at >= :now" {
+				writeError(w, "ValidationException", "unsupported condition expression: "+req.ConditionExpression)
+				return
+			}
+			now, _ := strconv.ParseInt(req.ExpressionAttributeValues[":now"].N, 10, 64)
+			lockID := req.Key[attributeLockID].S
+			item, ok := items[lockID]
+			if !ok || item.owner != req.ExpressionAttributeValues[":owner"].S || item.expiresAt < now {
+				writeError(w, "ConditionalCheckFailedException", "The conditional request failed")
+				return
+			}
+			item.expiresAt, _ = strconv.ParseInt(req.ExpressionAttributeValues[":expires_at"].N, 10, 64)
+			items[lockID] = item
+		default:
+			writeError(w, "UnknownOperationException", r.Header.Get("X-Amz-Target"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.0")
+		_, _ = w.Write([]byte("{}"))
+	}))
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+// newTestLocks はテストごとに作成したテーブルを使う Locks を返す。
+// NOTE: DYNAMODB_TEST_ENDPOINT が設定されている場合は DynamoDB Local を、未設定の場合はインプロセスのサーバーを使う
+func newTestLocks(t *testing.T) *Locks {
+	t.Helper()
+
+	endpoint := os.Getenv("DYNAMODB_TEST_ENDPOINT")
+	if endpoint == "" {
+		endpoint = newFakeDynamoDB(t)
+	}
+	config := aws.Config{
+		Region:       "ap-northeast-1",
+		BaseEndpoint: aws.String(endpoint),
+		Credentials:  credentials.NewStaticCredentialsProvider("test", "test", ""),
+	}
+
+	table := "keeput-lock-test-" + uuid.NewString()
+	_, err := dynamodb.NewFromConfig(config).CreateTable(context.Background(), &dynamodb.CreateTableInput{
+		TableName: aws.String(table),
+		AttributeDefinitions: []types.AttributeDefinition{
+			{AttributeName: aws.String(attributeLockID), AttributeType: types.ScalarAttributeTypeS},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{AttributeName: aws.String(attributeLockID), KeyType: types.KeyTypeHash},
+		},
+		BillingMode: types.BillingModePayPerRequest,
+	})
+	require.NoError(t, err)
+
+	return NewLocks(config, table)
+}
+
+func newLocker(locks *Locks) locker.Locker {
+	return locker.Locker{
+		Acquire: NewAcquire(locks),
+		Renew:   NewRenew(locks),
+		Release: NewRelease(locks),
+	}
+}
+
+func TestLocker(t *testing.T) {
+	lockertest.Run(t, func(t *testing.T) locker.Locker {
+		return newLocker(newTestLocks(t))
+	})
+}
+
+// NOTE: TTL による削除を待たずに、有効期限を過ぎたロックを取得できることを検証する
+func TestLocker_Expiry(t *testing.T) {
+	lockertest.RunExpiry(t, func(t *testing.T) (locker.Locker, lockertest.Advance) {
+		locks := newTestLocks(t)
+		now := time.Now()
+		locks.now = func() time.Time { return now }
+		return newLocker(locks), func(d time.Duration) {
+			now = now.Add(d)
+		}
+	})
+}
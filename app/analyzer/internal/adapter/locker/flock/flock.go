@@ -0,0 +1,103 @@
+package flock
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+// Locks は dir 配下のロックファイルに flock でロックを取得する。
+// NOTE: 同じホストで cron から起動する複数のプロセスで排他するために使う。
+// プロセスが終了するとロックは OS によって解放されるため、異常終了してもロックが残らず、TTL は使わない
+type Locks struct {
+	dir  string
+	mu   sync.Mutex
+	held map[string]heldLock
+}
+
+type heldLock struct {
+	token string
+	file  *os.File
+}
+
+func NewLocks(dir string) *Locks {
+	return &Locks{dir: dir, held: map[string]heldLock{}}
+}
+
+func NewAcquire(locks *Locks) locker.Acquire {
+	return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		return acquire(locks, lockID, ttl)
+	}
+}
+
+// NewRenew はこのプロセスがロックを保持している限り延長に成功する。
+func NewRenew(locks *Locks) locker.Renew {
+	return func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+		locks.mu.Lock()
+		defer locks.mu.Unlock()
+
+		held, ok := locks.held[lease.LockID]
+		return mo.Ok(ok && held.token == lease.Token)
+	}
+}
+
+func NewRelease(locks *Locks) locker.Release {
+	return func(ctx context.Context, lease *locker.Lease) error {
+		return release(locks, lease)
+	}
+}
+
+func acquire(locks *Locks, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+
+	if _, ok := locks.held[lockID]; ok {
+		return mo.Ok(mo.None[*locker.Lease]())
+	}
+
+	if err := os.MkdirAll(locks.dir, 0o755); err != nil {
+		return mo.Err[mo.Option[*locker.Lease]](err)
+	}
+	// NOTE: 解放時にロックファイルを削除すると、削除前に開いたプロセスと後に作成したプロセスの両方が取得できてしまうため、ファイルは残す
+	f, err := os.OpenFile(filepath.Join(locks.dir, url.PathEscape(lockID)+".lock"), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return mo.Err[mo.Option[*locker.Lease]](err)
+	}
+	if err := tryLock(f); err != nil {
+		_ = f.Close()
+		if errors.Is(err, errLocked) {
+			return mo.Ok(mo.None[*locker.Lease]())
+		}
+		return mo.Err[mo.Option[*locker.Lease]](err)
+	}
+
+	lease := &locker.Lease{LockID: lockID, Token: uuid.NewString(), TTL: ttl}
+	locks.held[lockID] = heldLock{token: lease.Token, file: f}
+	return mo.Ok(mo.Some(lease))
+}
+
+// release はこのプロセスで取得したロックを解放する。所有者でない場合は何もしない。
+func release(locks *Locks, lease *locker.Lease) error {
+	locks.mu.Lock()
+	defer locks.mu.Unlock()
+
+	held, ok := locks.held[lease.LockID]
+	if !ok || held.token != lease.Token {
+		return nil
+	}
+	delete(locks.held, lease.LockID)
+
+	if err := unlock(held.file); err != nil {
+		_ = held.file.Close()
+		return err
+	}
+	return held.file.Close()
+}
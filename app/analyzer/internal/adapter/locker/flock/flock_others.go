@@ -0,0 +1,18 @@
+//go:build !unix
+
+package flock
+
+import (
+	"errors"
+	"os"
+)
+
+var errLocked = errors.New("locked by another process")
+
+func tryLock(*os.File) error {
+	return errors.ErrUnsupported
+}
+
+func unlock(*os.File) error {
+	return errors.ErrUnsupported
+}
@@ -0,0 +1,54 @@
+package flock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/internal/lockertest"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocker(locks *Locks) locker.Locker {
+	return locker.Locker{
+		Acquire: NewAcquire(locks),
+		Renew:   NewRenew(locks),
+		Release: NewRelease(locks),
+	}
+}
+
+// NOTE: ロックはプロセスの終了時に OS によって解放され TTL を使わないため、lockertest.RunExpiry は実行しない
+func TestLocker(t *testing.T) {
+	lockertest.Run(t, func(t *testing.T) locker.Locker {
+		return newLocker(NewLocks(t.TempDir()))
+	})
+}
+
+// NOTE: 別のプロセスを想定し、同じディレクトリを使う Locks の間でも排他されることを確認する
+func TestLocker_SharedDir(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	lock, other := newLocker(NewLocks(dir)), newLocker(NewLocks(dir))
+
+	found, err := lock.Acquire(ctx, "usecase:analyze:2025-01-01", time.Minute).Get()
+	require.NoError(t, err)
+	lease, ok := found.Get()
+	require.True(t, ok)
+
+	found, err = other.Acquire(ctx, "usecase:analyze:2025-01-01", time.Minute).Get()
+	require.NoError(t, err)
+	assert.True(t, found.IsAbsent())
+
+	// NOTE: 他のプロセスが取得したロックは解放できない
+	require.NoError(t, other.Release(ctx, lease))
+	found, err = other.Acquire(ctx, "usecase:analyze:2025-01-01", time.Minute).Get()
+	require.NoError(t, err)
+	assert.True(t, found.IsAbsent())
+
+	require.NoError(t, lock.Release(ctx, lease))
+	found, err = other.Acquire(ctx, "usecase:analyze:2025-01-01", time.Minute).Get()
+	require.NoError(t, err)
+	assert.True(t, found.IsPresent())
+}
@@ -0,0 +1,181 @@
+// Package lockertest はロックの実装が満たすべき振る舞いを検証する共通のテストを提供する。
+package lockertest
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// NewLocker はテストケースごとに独立したロックを返す。
+type NewLocker = func(t *testing.T) locker.Locker
+
+const (
+	lockID = "usecase:analyze:2025-01-01"
+	ttl    = time.Minute
+)
+
+// Run は所有者のみがロックを延長・解放できることを検証する。
+func Run(t *testing.T, newLocker NewLocker) {
+	t.Run("acquire and release", func(t *testing.T) {
+		ctx := context.Background()
+		lock := newLocker(t)
+
+		lease := mustAcquire(t, lock, lockID)
+		assert.Equal(t, lockID, lease.LockID)
+		assert.NotEmpty(t, lease.Token)
+		assert.Equal(t, ttl, lease.TTL)
+
+		// NOTE: 取得済みのロックは解放されるまで取得できない
+		found, err := lock.Acquire(ctx, lockID, ttl).Get()
+		require.NoError(t, err)
+		assert.True(t, found.IsAbsent())
+
+		require.NoError(t, lock.Release(ctx, lease))
+
+		next := mustAcquire(t, lock, lockID)
+		assert.NotEqual(t, lease.Token, next.Token)
+	})
+
+	t.Run("locks are independent by lock id", func(t *testing.T) {
+		lock := newLocker(t)
+		mustAcquire(t, lock, lockID)
+		mustAcquire(t, lock, "usecase:digest:week:2025-01-01")
+	})
+
+	t.Run("renew", func(t *testing.T) {
+		ctx := context.Background()
+		lock := newLocker(t)
+		lease := mustAcquire(t, lock, lockID)
+
+		renewed, err := lock.Renew(ctx, lease).Get()
+		require.NoError(t, err)
+		assert.True(t, renewed)
+
+		require.NoError(t, lock.Release(ctx, lease))
+
+		// NOTE: 解放したロックは延長できない
+		renewed, err = lock.Renew(ctx, lease).Get()
+		require.NoError(t, err)
+		assert.False(t, renewed)
+	})
+
+	t.Run("only owner can renew and release", func(t *testing.T) {
+		ctx := context.Background()
+		lock := newLocker(t)
+		lease := mustAcquire(t, lock, lockID)
+		other := &locker.Lease{LockID: lockID, Token: "other", TTL: ttl}
+
+		renewed, err := lock.Renew(ctx, other).Get()
+		require.NoError(t, err)
+		assert.False(t, renewed)
+
+		require.NoError(t, lock.Release(ctx, other))
+		found, err := lock.Acquire(ctx, lockID, ttl).Get()
+		require.NoError(t, err)
+		assert.True(t, found.IsAbsent(), "lock must not be released by other owner")
+
+		require.NoError(t, lock.Release(ctx, lease))
+		mustAcquire(t, lock, lockID)
+	})
+
+	t.Run("release lock not acquired", func(t *testing.T) {
+		lock := newLocker(t)
+		assert.NoError(t, lock.Release(context.Background(), &locker.Lease{LockID: lockID, Token: "unknown", TTL: ttl}))
+	})
+
+	t.Run("only one of concurrent acquires succeeds", func(t *testing.T) {
+		ctx := context.Background()
+		lock := newLocker(t)
+
+		const n = 10
+		results := make([]bool, n)
+		var wg sync.WaitGroup
+		for i := range n {
+			wg.Go(func() {
+				found, err := lock.Acquire(ctx, lockID, ttl).Get()
+				assert.NoError(t, err)
+				results[i] = found.IsPresent()
+			})
+		}
+		wg.Wait()
+
+		acquired := 0
+		for _, result := range results {
+			if result {
+				acquired++
+			}
+		}
+		assert.Equal(t, 1, acquired)
+	})
+}
+
+// Advance はロックが参照する時刻を d だけ進める。
+type Advance = func(d time.Duration)
+
+// NewExpiringLocker はテストケースごとに独立したロックと、その時刻を進める関数を返す。
+type NewExpiringLocker = func(t *testing.T) (locker.Locker, Advance)
+
+// RunExpiry は解放されずに TTL を過ぎたロックの振る舞いを検証する。
+// NOTE: TTL の境界の扱いは実装ごとに異なるため、境界から離れた時刻で検証する
+func RunExpiry(t *testing.T, newLocker NewExpiringLocker) {
+	t.Run("unreleased lock can be acquired after ttl", func(t *testing.T) {
+		ctx := context.Background()
+		lock, advance := newLocker(t)
+		lease := mustAcquire(t, lock, lockID)
+
+		advance(ttl / 2)
+		found, err := lock.Acquire(ctx, lockID, ttl).Get()
+		require.NoError(t, err)
+		assert.True(t, found.IsAbsent(), "lock must be held before ttl")
+
+		advance(ttl)
+		next := mustAcquire(t, lock, lockID)
+		assert.NotEqual(t, lease.Token, next.Token)
+	})
+
+	t.Run("renew after expiry returns false", func(t *testing.T) {
+		ctx := context.Background()
+		lock, advance := newLocker(t)
+		lease := mustAcquire(t, lock, lockID)
+
+		advance(ttl + ttl/2)
+		renewed, err := lock.Renew(ctx, lease).Get()
+		require.NoError(t, err)
+		assert.False(t, renewed)
+	})
+
+	t.Run("renew extends ttl from renewal", func(t *testing.T) {
+		ctx := context.Background()
+		lock, advance := newLocker(t)
+		lease := mustAcquire(t, lock, lockID)
+
+		advance(ttl * 2 / 3)
+		renewed, err := lock.Renew(ctx, lease).Get()
+		require.NoError(t, err)
+		require.True(t, renewed)
+
+		// NOTE: 取得時の TTL は過ぎているが、延長後の TTL は過ぎていない
+		advance(ttl * 2 / 3)
+		found, err := lock.Acquire(ctx, lockID, ttl).Get()
+		require.NoError(t, err)
+		assert.True(t, found.IsAbsent(), "renewed lock must be held")
+
+		advance(ttl * 2 / 3)
+		mustAcquire(t, lock, lockID)
+	})
+}
+
+func mustAcquire(t *testing.T, lock locker.Locker, lockID string) *locker.Lease {
+	t.Helper()
+	found, err := lock.Acquire(context.Background(), lockID, ttl).Get()
+	require.NoError(t, err)
+	lease, ok := found.Get()
+	require.True(t, ok, "lock %s must be acquired", lockID)
+	return lease
+}
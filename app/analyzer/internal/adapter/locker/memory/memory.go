@@ -0,0 +1,70 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+type heldLock struct {
+	token     string
+	expiresAt time.Time
+}
+
+// Locks は取得済みのロックをプロセス内で保持する。
+// NOTE: 複数のプロセスからは共有できないため、テストや単一のプロセスで実行する場合に使う
+type Locks struct {
+	mu   sync.Mutex
+	held map[string]heldLock
+	now  func() time.Time
+}
+
+func NewLocks() *Locks {
+	return &Locks{held: map[string]heldLock{}, now: time.Now}
+}
+
+func NewAcquire(locks *Locks) locker.Acquire {
+	return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		locks.mu.Lock()
+		defer locks.mu.Unlock()
+
+		now := locks.now()
+		if held, ok := locks.held[lockID]; ok && now.Before(held.expiresAt) {
+			return mo.Ok(mo.None[*locker.Lease]())
+		}
+		lease := &locker.Lease{LockID: lockID, Token: uuid.NewString(), TTL: ttl}
+		locks.held[lockID] = heldLock{token: lease.Token, expiresAt: now.Add(ttl)}
+		return mo.Ok(mo.Some(lease))
+	}
+}
+
+func NewRenew(locks *Locks) locker.Renew {
+	return func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+		locks.mu.Lock()
+		defer locks.mu.Unlock()
+
+		now := locks.now()
+		held, ok := locks.held[lease.LockID]
+		if !ok || held.token != lease.Token || !now.Before(held.expiresAt) {
+			return mo.Ok(false)
+		}
+		locks.held[lease.LockID] = heldLock{token: lease.Token, expiresAt: now.Add(lease.TTL)}
+		return mo.Ok(true)
+	}
+}
+
+func NewRelease(locks *Locks) locker.Release {
+	return func(ctx context.Context, lease *locker.Lease) error {
+		locks.mu.Lock()
+		defer locks.mu.Unlock()
+
+		if held, ok := locks.held[lease.LockID]; ok && held.token == lease.Token {
+			delete(locks.held, lease.LockID)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/internal/lockertest"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+func newLocker(locks *Locks) locker.Locker {
+	return locker.Locker{
+		Acquire: NewAcquire(locks),
+		Renew:   NewRenew(locks),
+		Release: NewRelease(locks),
+	}
+}
+
+func TestLocker(t *testing.T) {
+	lockertest.Run(t, func(t *testing.T) locker.Locker {
+		return newLocker(NewLocks())
+	})
+}
+
+func TestLocker_Expiry(t *testing.T) {
+	lockertest.RunExpiry(t, func(t *testing.T) (locker.Locker, lockertest.Advance) {
+		locks := NewLocks()
+		now := time.Now()
+		locks.now = func() time.Time { return now }
+		return newLocker(locks), func(d time.Duration) {
+			now = now.Add(d)
+		}
+	})
+}
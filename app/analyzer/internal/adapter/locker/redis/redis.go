@@ -0,0 +1,90 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+const keyPrefix = "keeput:lock:"
+
+// NOTE: GET と PEXPIRE・DEL の間に他の所有者が取得しないよう、所有者の確認と更新は Lua スクリプトでアトミックに行う
+var (
+	renewScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`)
+	releaseScript = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+)
+
+// Locks はロック ID ごとのキーに所有者のトークンを SET NX PX で書き込み、ロックを取得する。
+// 期限はキーの有効期限で管理するため、延長されないロックは Redis が削除する。
+type Locks struct {
+	client *redis.Client
+}
+
+// NewLocks は redis://<user>:<password>@<host>:<port>/<db> 形式の url の Redis に接続する。
+func NewLocks(url string) (*Locks, error) {
+	opts, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, err
+	}
+	return &Locks{client: redis.NewClient(opts)}, nil
+}
+
+func NewAcquire(locks *Locks) locker.Acquire {
+	return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		return acquire(ctx, locks, lockID, ttl)
+	}
+}
+
+func NewRenew(locks *Locks) locker.Renew {
+	return func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+		return renew(ctx, locks, lease)
+	}
+}
+
+func NewRelease(locks *Locks) locker.Release {
+	return func(ctx context.Context, lease *locker.Lease) error {
+		return release(ctx, locks, lease)
+	}
+}
+
+func acquire(ctx context.Context, locks *Locks, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+	lease := &locker.Lease{LockID: lockID, Token: uuid.NewString(), TTL: ttl}
+	// NOTE: 延長と同じくミリ秒で期限を設定する。NX で書き込まれなかった場合は nil 応答になる
+	err := locks.client.Do(ctx, "SET", keyPrefix+lockID, lease.Token, "NX", "PX", ttl.Milliseconds()).Err()
+	if errors.Is(err, redis.Nil) {
+		return mo.Ok(mo.None[*locker.Lease]())
+	}
+	if err != nil {
+		return mo.Err[mo.Option[*locker.Lease]](err)
+	}
+	return mo.Ok(mo.Some(lease))
+}
+
+// renew は所有者のロックが期限切れで削除されていない場合のみ期限を延長する。
+func renew(ctx context.Context, locks *Locks, lease *locker.Lease) mo.Result[bool] {
+	renewed, err := renewScript.Run(ctx, locks.client, []string{keyPrefix + lease.LockID}, lease.Token, lease.TTL.Milliseconds()).Int()
+	if err != nil {
+		return mo.Err[bool](err)
+	}
+	return mo.Ok(renewed == 1)
+}
+
+// release は所有者のロックを解放する。他の所有者が取得している場合は何もしない。
+func release(ctx context.Context, locks *Locks, lease *locker.Lease) error {
+	return releaseScript.Run(ctx, locks.client, []string{keyPrefix + lease.LockID}, lease.Token).Err()
+}
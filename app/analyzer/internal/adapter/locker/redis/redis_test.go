@@ -0,0 +1,73 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/internal/lockertest"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLocks はインプロセスの Redis 互換サーバーを使う Locks を返す。
+func newTestLocks(t *testing.T) (*Locks, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+	locks, err := NewLocks("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_ = locks.client.Close()
+	})
+	return locks, server
+}
+
+func newLocker(locks *Locks) locker.Locker {
+	return locker.Locker{
+		Acquire: NewAcquire(locks),
+		Renew:   NewRenew(locks),
+		Release: NewRelease(locks),
+	}
+}
+
+func TestLocker(t *testing.T) {
+	lockertest.Run(t, func(t *testing.T) locker.Locker {
+		locks, _ := newTestLocks(t)
+		return newLocker(locks)
+	})
+}
+
+func TestLocker_Expiry(t *testing.T) {
+	lockertest.RunExpiry(t, func(t *testing.T) (locker.Locker, lockertest.Advance) {
+		locks, server := newTestLocks(t)
+		return newLocker(locks), server.FastForward
+	})
+}
+
+func TestLocker_Renew(t *testing.T) {
+	ctx := context.Background()
+	locks, server := newTestLocks(t)
+	lock := newLocker(locks)
+
+	found, err := lock.Acquire(ctx, "usecase:analyze:2025-01-01", time.Minute).Get()
+	require.NoError(t, err)
+	lease, ok := found.Get()
+	require.True(t, ok)
+
+	server.FastForward(30 * time.Second)
+	renewed, err := lock.Renew(ctx, lease).Get()
+	require.NoError(t, err)
+	assert.True(t, renewed)
+	assert.Equal(t, time.Minute, server.TTL(keyPrefix+"usecase:analyze:2025-01-01"), "extend ttl from now")
+}
+
+func TestLocker_Unavailable(t *testing.T) {
+	locks, server := newTestLocks(t)
+	server.Close()
+
+	got := NewAcquire(locks)(context.Background(), "usecase:analyze:2025-01-01", time.Minute)
+	assert.True(t, got.IsError())
+}
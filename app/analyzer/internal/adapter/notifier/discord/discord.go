@@ -7,11 +7,14 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/samber/lo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/apphttp"
 	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/notifier"
 )
@@ -30,8 +33,18 @@ func NewNotifyAnalysisReport() notifier.NotifyAnalysisReport {
 	}
 }
 
+func NewNotifyDigestReport() notifier.NotifyDigestReport {
+	return func(ctx context.Context, report *model.DigestReport) error {
+		return notify(ctx, config.DiscordWebhookURL(), buildDigestMessage(report))
+	}
+}
+
 func notifyAnalysisReport(ctx context.Context, webhookURL string, report *model.AnalysisReport) error {
-	body := reqBody{Content: buildMessage(report)}
+	return notify(ctx, webhookURL, buildMessage(report))
+}
+
+func notify(ctx context.Context, webhookURL string, content string) error {
+	body := reqBody{Content: content}
 	payload, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request body: %w", err)
@@ -58,9 +71,61 @@ func notifyAnalysisReport(ctx context.Context, webhookURL string, report *model.
 }
 
 func buildMessage(report *model.AnalysisReport) string {
-	return lo.Ternary(
+	msg := lo.Ternary(
 		report.IsGoalAchieved,
 		"目標達成です🎊よく頑張りました！",
 		"目標未達です😢これから頑張りましょう！",
 	)
+	if report.EvaluationSkipped {
+		msg = "休止期間中のため目標の評価をお休みしています🏖️"
+	} else if report.ExtendedDays > 0 {
+		msg += fmt.Sprintf("\n休止期間の %d 日分だけ目標期間を延長しています", report.ExtendedDays)
+	}
+	if report.TargetPoints > 0 && !report.EvaluationSkipped {
+		msg += fmt.Sprintf(
+			"\nポイント: %d/%d %s",
+			report.EarnedPoints,
+			report.TargetPoints,
+			lo.Ternary(report.IsPointsGoalAchieved, "(達成)", "(未達)"),
+		)
+	}
+	if entry, ok := report.LatestEntry.Get(); ok {
+		msg += fmt.Sprintf(
+			"\n最新の%s: %s「%s」(%s)",
+			lo.Ternary(entry.Platform.Type.Kind() == model.EntryKindTalk, "登壇", "記事"),
+			entry.PublishedAt.In(lo.ToPtr(date.LocationJST())).Format(time.DateOnly),
+			entry.Title,
+			entry.Platform.Name,
+		)
+	}
+	if len(report.DegradedSources) > 0 {
+		msg += fmt.Sprintf("\n⚠️ 障害が続いているため %s の取得をスキップしました", strings.Join(report.DegradedSources, ", "))
+	}
+	return msg
+}
+
+func buildDigestMessage(report *model.DigestReport) string {
+	jst := lo.ToPtr(date.LocationJST())
+	msg := fmt.Sprintf(
+		"📊 %s〜%s のアウトプット\n投稿数: %d (前期間比 %+d)\n文字数: %d (前期間比 %+d)\nポイント: %d (前期間比 %+d)",
+		report.PeriodStart.In(jst).Format(time.DateOnly),
+		report.PeriodEnd.In(jst).Format(time.DateOnly),
+		report.Current.Entries,
+		report.Current.Entries-report.Previous.Entries,
+		report.Current.Characters,
+		report.Current.Characters-report.Previous.Characters,
+		report.Current.Points,
+		report.Current.Points-report.Previous.Points,
+	)
+	if len(report.Current.Platforms) > 0 {
+		msg += "\nプラットフォーム別: " + strings.Join(lo.Map(report.Current.Platforms, func(p model.PlatformCount, _ int) string {
+			return fmt.Sprintf("%s %d", p.Platform, p.Entries)
+		}), ", ")
+	}
+	if len(report.TopCategories) > 0 {
+		msg += "\nよく書いたカテゴリ: " + strings.Join(lo.Map(report.TopCategories, func(c model.CategoryCount, _ int) string {
+			return fmt.Sprintf("%s (%d)", c.Category, c.Entries)
+		}), ", ")
+	}
+	return msg
 }
@@ -0,0 +1,118 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMessage(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *model.AnalysisReport
+		want   string
+	}{
+		{
+			"show latest article",
+			&model.AnalysisReport{
+				IsGoalAchieved: true,
+				LatestEntry: mo.Some(&model.Entry{
+					Title:       "Go 言語の slice について",
+					PublishedAt: time.Date(2025, 1, 9, 1, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformZenn(),
+				}),
+			},
+			"目標達成です🎊よく頑張りました！\n最新の記事: 2025-01-09「Go 言語の slice について」(Zenn)",
+		},
+		{
+			"show latest talk",
+			&model.AnalysisReport{
+				IsGoalAchieved: true,
+				LatestEntry: mo.Some(&model.Entry{
+					Title:       "Go で作るアウトプット応援ツール",
+					PublishedAt: time.Date(2025, 1, 8, 20, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformSpeakerDeck(3),
+				}),
+			},
+			"目標達成です🎊よく頑張りました！\n最新の登壇: 2025-01-09「Go で作るアウトプット応援ツール」(Speaker Deck)",
+		},
+		{
+			"show degraded sources",
+			&model.AnalysisReport{
+				IsGoalAchieved:  false,
+				LatestEntry:     mo.None[*model.Entry](),
+				DegradedSources: []string{"hatena", "zenn"},
+			},
+			"目標未達です😢これから頑張りましょう！\n⚠️ 障害が続いているため hatena, zenn の取得をスキップしました",
+		},
+		{
+			"show points",
+			&model.AnalysisReport{
+				IsGoalAchieved:       true,
+				LatestEntry:          mo.None[*model.Entry](),
+				EarnedPoints:         4,
+				TargetPoints:         5,
+				IsPointsGoalAchieved: false,
+			},
+			"目標達成です🎊よく頑張りました！\nポイント: 4/5 (未達)",
+		},
+		{
+			"skip evaluation during pause",
+			&model.AnalysisReport{
+				LatestEntry:       mo.None[*model.Entry](),
+				TargetPoints:      5,
+				Paused:            true,
+				EvaluationSkipped: true,
+			},
+			"休止期間中のため目標の評価をお休みしています🏖️",
+		},
+		{
+			"show extended days",
+			&model.AnalysisReport{
+				IsGoalAchieved: true,
+				LatestEntry:    mo.None[*model.Entry](),
+				ExtendedDays:   3,
+			},
+			"目標達成です🎊よく頑張りました！\n休止期間の 3 日分だけ目標期間を延長しています",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, buildMessage(tt.report))
+		})
+	}
+}
+
+func TestBuildDigestMessage(t *testing.T) {
+	report := &model.DigestReport{
+		PeriodStart: time.Date(2025, 1, 7, 15, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 14, 15, 0, 0, 0, time.UTC),
+		Current: model.DigestStats{
+			Entries:    3,
+			Characters: 5000,
+			Points:     6,
+			Platforms: []model.PlatformCount{
+				{Platform: "Zenn", Entries: 2},
+				{Platform: "はてなブログ", Entries: 1},
+			},
+		},
+		Previous: model.DigestStats{
+			Entries:    4,
+			Characters: 3000,
+			Points:     4,
+		},
+		TopCategories: []model.CategoryCount{
+			{Category: "Rust", Entries: 2},
+			{Category: "Go", Entries: 1},
+		},
+	}
+
+	assert.Equal(
+		t,
+		"📊 2025-01-08〜2025-01-15 のアウトプット\n投稿数: 3 (前期間比 -1)\n文字数: 5000 (前期間比 +2000)\nポイント: 6 (前期間比 +2)\nプラットフォーム別: Zenn 2, はてなブログ 1\nよく書いたカテゴリ: Rust (2), Go (1)",
+		buildDigestMessage(report),
+	)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
+)
+
+// NewLoadPausePeriods は環境変数 PAUSE_PERIODS で設定された休止期間を返す。
+func NewLoadPausePeriods() pause.LoadPausePeriods {
+	return func(ctx context.Context) mo.Result[[]model.PausePeriod] {
+		configured, err := config.PausePeriods()
+		if err != nil {
+			return mo.Err[[]model.PausePeriod](err)
+		}
+
+		periods := make([]model.PausePeriod, 0, len(configured))
+		for i, c := range configured {
+			period, err := model.NewPausePeriod(c.Start, c.End, c.Reason)
+			if err != nil {
+				return mo.Err[[]model.PausePeriod](fmt.Errorf("PAUSE_PERIODS[%d]: %w", i, err))
+			}
+			periods = append(periods, period)
+		}
+		return mo.Ok(periods)
+	}
+}
@@ -0,0 +1,82 @@
+package s3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/internal/s3client"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
+)
+
+const (
+	key = "pause_periods.json"
+)
+
+var s3ClientOnce sync.Once
+var s3Client *s3.Client
+
+func initS3Client(config aws.Config) {
+	s3ClientOnce.Do(func() {
+		s3Client = s3client.New(config)
+	})
+}
+
+func NewLoadPausePeriods(config aws.Config) pause.LoadPausePeriods {
+	initS3Client(config)
+	return loadPausePeriods
+}
+
+func NewSavePausePeriods(config aws.Config) pause.SavePausePeriods {
+	initS3Client(config)
+	return savePausePeriods
+}
+
+func loadPausePeriods(ctx context.Context) mo.Result[[]model.PausePeriod] {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(config.S3BucketName()),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return mo.Ok([]model.PausePeriod{})
+		}
+		return mo.Err[[]model.PausePeriod](err)
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return mo.Err[[]model.PausePeriod](err)
+	}
+	var periods []model.PausePeriod
+	if err := json.Unmarshal(b, &periods); err != nil {
+		return mo.Err[[]model.PausePeriod](err)
+	}
+	return mo.Ok(periods)
+}
+
+func savePausePeriods(ctx context.Context, periods []model.PausePeriod) error {
+	b, err := json.Marshal(periods)
+	if err != nil {
+		return err
+	}
+
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      aws.String(config.S3BucketName()),
+		Key:         aws.String(key),
+		Body:        bytes.NewReader(b),
+		ContentType: aws.String("application/json"),
+	})
+	return err
+}
@@ -0,0 +1,113 @@
+package file
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+// NewPersistAnalysisReport は dir 配下に S3 と同じキーでレポートを保存する。
+func NewPersistAnalysisReport(dir string) persister.PersistAnalysisReport {
+	return func(ctx context.Context, report *model.AnalysisReport) error {
+		return persistAnalysisReport(ctx, dir, report)
+	}
+}
+
+func persistAnalysisReport(ctx context.Context, dir string, report *model.AnalysisReport) error {
+	b, err := internal.EncodeAnalysisReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	return writeFile(filepath.Join(dir, filepath.FromSlash(internal.AnalysisReportKey(report.Goal, report.GeneratedAt))), b)
+}
+
+// writeFile は一時ファイルに書き込んでから置き換える。
+// NOTE: 書き込み途中で失敗しても、既存のレポートが壊れないようにする
+func writeFile(name string, b []byte) error {
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(name), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(b); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), name)
+}
+
+func NewMaintainReports(dir string) persister.MaintainReports {
+	return func(ctx context.Context, policy *persister.MaintenancePolicy) mo.Result[*persister.MaintenanceResult] {
+		result, err := internal.Maintain(ctx, storage{dir: dir}, policy)
+		if err != nil {
+			return mo.Err[*persister.MaintenanceResult](err)
+		}
+		return mo.Ok(result)
+	}
+}
+
+// storage は dir 配下のファイルを S3 と同じキーで internal.Storage として扱う。
+type storage struct {
+	dir string
+}
+
+// List は書き込み途中の一時ファイルを除いたキーを返す。
+func (s storage) List(_ context.Context, prefix string) ([]string, error) {
+	var keys []string
+	err := filepath.WalkDir(s.dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || strings.HasPrefix(d.Name(), ".tmp-") {
+			return nil
+		}
+		rel, err := filepath.Rel(s.dir, name)
+		if err != nil {
+			return err
+		}
+		if key := filepath.ToSlash(rel); strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return keys, err
+}
+
+func (s storage) Get(_ context.Context, key string) ([]byte, error) {
+	b, err := os.ReadFile(s.name(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, internal.ErrObjectNotFound
+	}
+	return b, err
+}
+
+func (s storage) Put(_ context.Context, key string, b []byte) error {
+	return writeFile(s.name(key), b)
+}
+
+func (s storage) Delete(_ context.Context, key string) error {
+	return os.Remove(s.name(key))
+}
+
+func (s storage) name(key string) string {
+	return filepath.Join(s.dir, filepath.FromSlash(key))
+}
@@ -0,0 +1,79 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistAnalysisReport(t *testing.T) {
+	dir := t.TempDir()
+	persist := NewPersistAnalysisReport(dir)
+
+	report := &model.AnalysisReport{
+		Goal:         model.GoalTypeRecentWeek,
+		GeneratedAt:  time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC),
+		EarnedPoints: 1,
+	}
+	require.NoError(t, persist(context.Background(), report))
+	// NOTE: 同じ日の再実行では上書きされる
+	report.EarnedPoints = 2
+	require.NoError(t, persist(context.Background(), report))
+
+	b, err := os.ReadFile(filepath.Join(dir, "analysis_report", "recent_week", "2025", "01", "10", "data.json"))
+	require.NoError(t, err)
+	got, err := internal.DecodeAnalysisReport(b)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.EarnedPoints)
+
+	entries, err := os.ReadDir(filepath.Join(dir, "analysis_report", "recent_week", "2025", "01", "10"))
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestMaintainReports(t *testing.T) {
+	ctx := context.Background()
+	jst := lo.ToPtr(date.LocationJST())
+	dir := t.TempDir()
+	persist := NewPersistAnalysisReport(dir)
+
+	for day := 1; day <= 2; day++ {
+		require.NoError(t, persist(ctx, &model.AnalysisReport{
+			Goal:         model.GoalTypeRecentWeek,
+			GeneratedAt:  time.Date(2025, 1, day, 9, 0, 0, 0, jst),
+			EarnedPoints: day,
+		}))
+	}
+	// NOTE: 書き込み途中の一時ファイルは対象外とする
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "analysis_report", "recent_week", "2025", "01", "02", ".tmp-1"), nil, 0o644))
+
+	got, err := NewMaintainReports(dir)(ctx, &persister.MaintenancePolicy{
+		Now:             time.Date(2025, 2, 10, 9, 0, 0, 0, jst),
+		RetentionCutoff: time.Date(2025, 1, 2, 0, 0, 0, 0, jst),
+		Archive:         true,
+	}).Get()
+	require.NoError(t, err)
+	assert.Equal(t, &persister.MaintenanceResult{Compacted: 1, MonthlyFiles: 1, Archived: 1}, got)
+
+	_, err = os.Stat(filepath.Join(dir, "archive", "analysis_report", "recent_week", "2025", "01", "01", "data.json"))
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "analysis_report", "recent_week", "2025", "01", "02", "data.json"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	b, err := os.ReadFile(filepath.Join(dir, "analysis_report_monthly", "recent_week", "2025", "01", "data.json"))
+	require.NoError(t, err)
+	reports, err := internal.DecodeMonthlyAnalysisReports(b)
+	require.NoError(t, err)
+	require.Len(t, reports, 1)
+	assert.Equal(t, 2, reports[0].EarnedPoints)
+}
@@ -0,0 +1,231 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+// analysisReportV2 はスキーマバージョン 2 のレポート本体。
+// NOTE: model の変更が保存形式に波及しないよう、model とは別に保存形式を定義する
+type analysisReportV2 struct {
+	IsGoalAchieved       bool              `json:"is_goal_achieved"`
+	LatestEntry          *entryV2          `json:"latest_entry"`
+	EarnedPoints         int               `json:"earned_points"`
+	TargetPoints         int               `json:"target_points"`
+	IsPointsGoalAchieved bool              `json:"is_points_goal_achieved"`
+	Metrics              contentMetricsV2  `json:"metrics"`
+	Paused               bool              `json:"paused"`
+	EvaluationSkipped    bool              `json:"evaluation_skipped"`
+	ExtendedDays         int               `json:"extended_days"`
+	ExcludedEntries      []excludedEntryV2 `json:"excluded_entries"`
+	DegradedSources      []string          `json:"degraded_sources"`
+}
+
+type entryV2 struct {
+	Title       string     `json:"title"`
+	Body        string     `json:"body"`
+	Tags        []string   `json:"tags"`
+	PublishedAt time.Time  `json:"published_at"`
+	Platform    platformV2 `json:"platform"`
+}
+
+type platformV2 struct {
+	Type     int    `json:"type"`
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Weight   int    `json:"weight"`
+}
+
+type excludedEntryV2 struct {
+	Entry  entryV2 `json:"entry"`
+	Reason string  `json:"reason"`
+}
+
+// NOTE: バージョン 1 でも同じ形式で保存していたため、バージョン 1 の読み込みにも使う
+type contentMetricsV2 struct {
+	Entries            int `json:"entries"`
+	AverageCharacters  int `json:"average_characters"`
+	Characters         int `json:"characters"`
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	CodeBlocks         int `json:"code_blocks"`
+	Images             int `json:"images"`
+	Headings           int `json:"headings"`
+}
+
+func newAnalysisReportV2(report *model.AnalysisReport) *analysisReportV2 {
+	return &analysisReportV2{
+		IsGoalAchieved: report.IsGoalAchieved,
+		LatestEntry: lo.TernaryF(
+			report.LatestEntry.IsPresent(),
+			func() *entryV2 { return lo.ToPtr(newEntryV2(report.LatestEntry.MustGet())) },
+			func() *entryV2 { return nil },
+		),
+		EarnedPoints:         report.EarnedPoints,
+		TargetPoints:         report.TargetPoints,
+		IsPointsGoalAchieved: report.IsPointsGoalAchieved,
+		Metrics:              newContentMetricsV2(report.Metrics),
+		Paused:               report.Paused,
+		EvaluationSkipped:    report.EvaluationSkipped,
+		ExtendedDays:         report.ExtendedDays,
+		ExcludedEntries: lo.Map(report.ExcludedEntries, func(e *model.ExcludedEntry, _ int) excludedEntryV2 {
+			return excludedEntryV2{Entry: newEntryV2(e.Entry), Reason: string(e.Reason)}
+		}),
+		DegradedSources: report.DegradedSources,
+	}
+}
+
+func newEntryV2(entry *model.Entry) entryV2 {
+	return entryV2{
+		Title:       entry.Title,
+		Body:        entry.Body,
+		Tags:        entry.Tags,
+		PublishedAt: entry.PublishedAt,
+		Platform: platformV2{
+			Type:     int(entry.Platform.Type),
+			Name:     entry.Platform.Name,
+			Priority: entry.Platform.Priority,
+			Weight:   entry.Platform.Weight,
+		},
+	}
+}
+
+func newContentMetricsV2(metrics model.ContentMetricsSummary) contentMetricsV2 {
+	return contentMetricsV2{
+		Entries:            metrics.Entries,
+		AverageCharacters:  metrics.AverageCharacters,
+		Characters:         metrics.Characters,
+		ReadingTimeMinutes: metrics.ReadingTimeMinutes,
+		CodeBlocks:         metrics.CodeBlocks,
+		Images:             metrics.Images,
+		Headings:           metrics.Headings,
+	}
+}
+
+func (r *analysisReportV2) toModel(goal model.GoalType, generatedAt time.Time) *model.AnalysisReport {
+	return &model.AnalysisReport{
+		Goal:           goal,
+		GeneratedAt:    generatedAt,
+		IsGoalAchieved: r.IsGoalAchieved,
+		LatestEntry: lo.TernaryF(
+			r.LatestEntry != nil,
+			func() mo.Option[*model.Entry] { return mo.Some(r.LatestEntry.toModel()) },
+			mo.None[*model.Entry],
+		),
+		EarnedPoints:         r.EarnedPoints,
+		TargetPoints:         r.TargetPoints,
+		IsPointsGoalAchieved: r.IsPointsGoalAchieved,
+		Metrics:              r.Metrics.toModel(),
+		Paused:               r.Paused,
+		EvaluationSkipped:    r.EvaluationSkipped,
+		ExtendedDays:         r.ExtendedDays,
+		ExcludedEntries: lo.Map(r.ExcludedEntries, func(e excludedEntryV2, _ int) *model.ExcludedEntry {
+			return &model.ExcludedEntry{Entry: e.Entry.toModel(), Reason: model.ExclusionReason(e.Reason)}
+		}),
+		DegradedSources: r.DegradedSources,
+	}
+}
+
+func (e *entryV2) toModel() *model.Entry {
+	return &model.Entry{
+		Title:       e.Title,
+		Body:        e.Body,
+		Tags:        e.Tags,
+		PublishedAt: e.PublishedAt,
+		Platform: model.EntryPlatform{
+			Type:     model.EntryPlatformType(e.Platform.Type),
+			Name:     e.Platform.Name,
+			Priority: e.Platform.Priority,
+			Weight:   e.Platform.Weight,
+		},
+	}
+}
+
+func (m contentMetricsV2) toModel() model.ContentMetricsSummary {
+	return model.ContentMetricsSummary{
+		Entries:           m.Entries,
+		AverageCharacters: m.AverageCharacters,
+		ContentMetrics: model.ContentMetrics{
+			Characters:         m.Characters,
+			ReadingTimeMinutes: m.ReadingTimeMinutes,
+			CodeBlocks:         m.CodeBlocks,
+			Images:             m.Images,
+			Headings:           m.Headings,
+		},
+	}
+}
+
+// analysisReportV1 はスキーマバージョン 1 のレポート。model.AnalysisReport をそのまま保存していた。
+type analysisReportV1 struct {
+	Goal                 int               `json:"goal"`
+	GeneratedAt          time.Time         `json:"generated_at"`
+	IsGoalAchieved       bool              `json:"is_goal_achieved"`
+	LatestEntry          *entryV1          `json:"latest_entry"`
+	EarnedPoints         int               `json:"earned_points"`
+	TargetPoints         int               `json:"target_points"`
+	IsPointsGoalAchieved bool              `json:"is_points_goal_achieved"`
+	Metrics              contentMetricsV2  `json:"metrics"`
+	Paused               bool              `json:"paused"`
+	EvaluationSkipped    bool              `json:"evaluation_skipped"`
+	ExtendedDays         int               `json:"extended_days"`
+	ExcludedEntries      []excludedEntryV1 `json:"excluded_entries"`
+	DegradedSources      []string          `json:"degraded_sources"`
+}
+
+// NOTE: model.Entry に JSON タグがなかったため、フィールド名がそのままキーになっている
+type entryV1 struct {
+	Title       string
+	Body        string
+	Tags        []string
+	PublishedAt time.Time
+	Platform    struct {
+		Type     int
+		Name     string
+		Priority int
+		Weight   int
+	}
+}
+
+type excludedEntryV1 struct {
+	Entry  entryV1 `json:"entry"`
+	Reason string  `json:"reason"`
+}
+
+func (r *analysisReportV1) toModel() *model.AnalysisReport {
+	return (&analysisReportV2{
+		IsGoalAchieved: r.IsGoalAchieved,
+		LatestEntry: lo.TernaryF(
+			r.LatestEntry != nil,
+			func() *entryV2 { return lo.ToPtr(r.LatestEntry.toV2()) },
+			func() *entryV2 { return nil },
+		),
+		EarnedPoints:         r.EarnedPoints,
+		TargetPoints:         r.TargetPoints,
+		IsPointsGoalAchieved: r.IsPointsGoalAchieved,
+		Metrics:              r.Metrics,
+		Paused:               r.Paused,
+		EvaluationSkipped:    r.EvaluationSkipped,
+		ExtendedDays:         r.ExtendedDays,
+		ExcludedEntries: lo.Map(r.ExcludedEntries, func(e excludedEntryV1, _ int) excludedEntryV2 {
+			return excludedEntryV2{Entry: e.Entry.toV2(), Reason: e.Reason}
+		}),
+		DegradedSources: r.DegradedSources,
+	}).toModel(model.GoalType(r.Goal), r.GeneratedAt)
+}
+
+func (e *entryV1) toV2() entryV2 {
+	return entryV2{
+		Title:       e.Title,
+		Body:        e.Body,
+		Tags:        e.Tags,
+		PublishedAt: e.PublishedAt,
+		Platform: platformV2{
+			Type:     e.Platform.Type,
+			Name:     e.Platform.Name,
+			Priority: e.Platform.Priority,
+			Weight:   e.Platform.Weight,
+		},
+	}
+}
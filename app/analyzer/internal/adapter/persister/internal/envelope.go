@@ -0,0 +1,124 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+const (
+	// SchemaVersion は保存するレポートのスキーマバージョン。
+	// NOTE: フィールドの削除や意味の変更など、既存の読み手が壊れる変更を行う場合に上げる。
+	// バージョン 1 はエンベロープを持たず model.AnalysisReport をそのまま保存していた形式
+	SchemaVersion = 2
+
+	timezone = "Asia/Tokyo"
+
+	kindAnalysisReport = "analysis_report"
+	kindDigestReport   = "digest_report"
+)
+
+// Envelope は保存するレポートのメタデータと本体を表す。
+type Envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	RunID         string `json:"run_id"`
+	// 分析結果では目標、ダイジェストでは集計期間を記録する
+	Goal        string          `json:"goal,omitempty"`
+	Period      string          `json:"period,omitempty"`
+	Timezone    string          `json:"timezone"`
+	GeneratedAt time.Time       `json:"generated_at"`
+	Report      json.RawMessage `json:"report"`
+}
+
+// AnalysisReportKey は目標と JST の日付から決まるキーを返す。
+// NOTE: 同じ日の再実行では同じキーに上書きされる
+func AnalysisReportKey(goal model.GoalType, now time.Time) string {
+	return reportKey(kindAnalysisReport, goal.String(), now)
+}
+
+// DigestReportKey は集計期間と JST の日付から決まるキーを返す。
+func DigestReportKey(period model.DigestPeriod, now time.Time) string {
+	return reportKey(kindDigestReport, period.String(), now)
+}
+
+func reportKey(kind, name string, now time.Time) string {
+	return path.Join(kind, name, now.In(lo.ToPtr(date.LocationJST())).Format("2006/01/02"), "data.json")
+}
+
+// ReportDate はレポートを識別する JST の日付 (YYYY-MM-DD) を返す。
+func ReportDate(t time.Time) string {
+	return t.In(lo.ToPtr(date.LocationJST())).Format(time.DateOnly)
+}
+
+// LegacyAnalysisReportPrefix はバージョン 1 のレポートが保存されている、実行日時から決まるキーの prefix を返す。
+func LegacyAnalysisReportPrefix(now time.Time) string {
+	return path.Join(kindAnalysisReport, now.Format("2006/01/02")) + "/"
+}
+
+func EncodeAnalysisReport(ctx context.Context, report *model.AnalysisReport) ([]byte, error) {
+	return encode(ctx, &Envelope{Goal: report.Goal.String(), GeneratedAt: report.GeneratedAt}, newAnalysisReportV2(report))
+}
+
+func EncodeDigestReport(ctx context.Context, report *model.DigestReport) ([]byte, error) {
+	return encode(ctx, &Envelope{Period: report.Period.String(), GeneratedAt: report.PeriodEnd}, report)
+}
+
+// encode は envelope に共通のメタデータと本体を設定して JSON に変換する。
+func encode(ctx context.Context, envelope *Envelope, report any) ([]byte, error) {
+	b, err := json.Marshal(report)
+	if err != nil {
+		return nil, err
+	}
+	envelope.SchemaVersion = SchemaVersion
+	envelope.RunID, _ = appctx.GetRunID(ctx)
+	envelope.Timezone = timezone
+	envelope.Report = b
+	return json.Marshal(envelope)
+}
+
+// DecodeAnalysisReport は全てのスキーマバージョンのレポートを読み込む。
+func DecodeAnalysisReport(b []byte) (*model.AnalysisReport, error) {
+	// NOTE: バージョン 1 の goal は数値のため、先にバージョンのみを読み込んで形式を判別する
+	var version struct {
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(b, &version); err != nil {
+		return nil, err
+	}
+
+	switch version.SchemaVersion {
+	// NOTE: バージョン 1 は schema_version を持たない
+	case 0, 1:
+		var report analysisReportV1
+		if err := json.Unmarshal(b, &report); err != nil {
+			return nil, err
+		}
+		return report.toModel(), nil
+	case 2:
+		var envelope Envelope
+		if err := json.Unmarshal(b, &envelope); err != nil {
+			return nil, err
+		}
+		var report analysisReportV2
+		if err := json.Unmarshal(envelope.Report, &report); err != nil {
+			return nil, err
+		}
+		return report.toModel(parseGoalType(envelope.Goal), envelope.GeneratedAt), nil
+	default:
+		return nil, fmt.Errorf("unsupported schema version: %d", version.SchemaVersion)
+	}
+}
+
+func parseGoalType(s string) model.GoalType {
+	return lo.Switch[string, model.GoalType](s).
+		Case(model.GoalTypeRecentWeek.String(), model.GoalTypeRecentWeek).
+		Case(model.GoalTypeRecentMonth.String(), model.GoalTypeRecentMonth).
+		Default(0)
+}
@@ -0,0 +1,110 @@
+package internal
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeAnalysisReport(t *testing.T) {
+	report := &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentWeek,
+		GeneratedAt:    time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			Body:        "<p>slice は参照型です</p>",
+			Tags:        []string{"Go"},
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints: 1,
+		Metrics: model.ContentMetricsSummary{
+			Entries:           1,
+			AverageCharacters: 10,
+			ContentMetrics: model.ContentMetrics{
+				Characters:         10,
+				ReadingTimeMinutes: 1,
+			},
+		},
+		ExcludedEntries: []*model.ExcludedEntry{
+			{
+				Entry: &model.Entry{
+					Title:       "メモ",
+					PublishedAt: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformHatena(),
+				},
+				Reason: model.ExclusionReasonTooShort,
+			},
+		},
+		DegradedSources: []string{"hatena"},
+	}
+
+	b, err := EncodeAnalysisReport(appctx.SetRunID(context.Background(), "run-1"), report)
+	require.NoError(t, err)
+
+	var envelope Envelope
+	require.NoError(t, json.Unmarshal(b, &envelope))
+	assert.Equal(t, SchemaVersion, envelope.SchemaVersion)
+	assert.Equal(t, "run-1", envelope.RunID)
+	assert.Equal(t, "recent_week", envelope.Goal)
+	assert.Equal(t, "Asia/Tokyo", envelope.Timezone)
+
+	got, err := DecodeAnalysisReport(b)
+	require.NoError(t, err)
+	assert.Equal(t, report, got)
+}
+
+func TestDecodeAnalysisReport_V1(t *testing.T) {
+	// NOTE: スキーマバージョン 1 では model.AnalysisReport をそのまま保存していた
+	b := []byte(`{
+		"goal": 2,
+		"generated_at": "2025-01-10T00:00:00Z",
+		"is_goal_achieved": true,
+		"latest_entry": {
+			"Title": "Go 言語の slice について",
+			"Body": "",
+			"Tags": null,
+			"PublishedAt": "2025-01-09T10:00:00Z",
+			"Platform": {"Type": 1, "Name": "Zenn", "Priority": 2, "Weight": 1}
+		},
+		"earned_points": 1
+	}`)
+
+	got, err := DecodeAnalysisReport(b)
+	require.NoError(t, err)
+	assert.Equal(t, &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentMonth,
+		GeneratedAt:    time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints:    1,
+		ExcludedEntries: []*model.ExcludedEntry{},
+	}, got)
+}
+
+func TestDecodeAnalysisReport_UnsupportedVersion(t *testing.T) {
+	_, err := DecodeAnalysisReport([]byte(`{"schema_version": 99, "report": {}}`))
+	assert.Error(t, err)
+}
+
+func TestAnalysisReportKey(t *testing.T) {
+	// NOTE: 日付は JST で決まる
+	now := time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, "analysis_report/recent_week/2025/01/10/data.json", AnalysisReportKey(model.GoalTypeRecentWeek, now))
+	assert.Equal(t, AnalysisReportKey(model.GoalTypeRecentWeek, now), AnalysisReportKey(model.GoalTypeRecentWeek, now.In(lo.ToPtr(date.LocationJST())).Add(time.Hour)))
+	assert.Equal(t, "digest_report/month/2025/01/10/data.json", DigestReportKey(model.DigestPeriodMonth, now))
+}
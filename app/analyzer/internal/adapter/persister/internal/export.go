@@ -0,0 +1,160 @@
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+const (
+	ExportFormatNDJSON  = "ndjson"
+	ExportFormatParquet = "parquet"
+
+	exportPrefix          = "export/analysis_report"
+	compactedExportPrefix = "export/analysis_report_compacted"
+)
+
+// ExportRow は Athena や DuckDB から集計するためにレポートを平坦化した 1 行を表す。
+// NOTE: パーティションのカラム (dt, goal) と名前が重複すると Athena でエラーになるため、
+// 日付と目標は report_date と goal_type として持つ
+type ExportRow struct {
+	ReportDate             string    `json:"report_date" parquet:"report_date"`
+	GoalType               string    `json:"goal_type" parquet:"goal_type"`
+	RunID                  string    `json:"run_id" parquet:"run_id"`
+	GeneratedAt            time.Time `json:"generated_at" parquet:"generated_at,timestamp(millisecond)"`
+	IsGoalAchieved         bool      `json:"is_goal_achieved" parquet:"is_goal_achieved"`
+	LatestEntryTitle       string    `json:"latest_entry_title" parquet:"latest_entry_title"`
+	LatestEntryPlatform    string    `json:"latest_entry_platform" parquet:"latest_entry_platform"`
+	LatestEntryPublishedAt time.Time `json:"latest_entry_published_at" parquet:"latest_entry_published_at,timestamp(millisecond)"`
+	EarnedPoints           int64     `json:"earned_points" parquet:"earned_points"`
+	TargetPoints           int64     `json:"target_points" parquet:"target_points"`
+	IsPointsGoalAchieved   bool      `json:"is_points_goal_achieved" parquet:"is_points_goal_achieved"`
+	Entries                int64     `json:"entries" parquet:"entries"`
+	Characters             int64     `json:"characters" parquet:"characters"`
+	ReadingTimeMinutes     int64     `json:"reading_time_minutes" parquet:"reading_time_minutes"`
+	CodeBlocks             int64     `json:"code_blocks" parquet:"code_blocks"`
+	Images                 int64     `json:"images" parquet:"images"`
+	Headings               int64     `json:"headings" parquet:"headings"`
+	Paused                 bool      `json:"paused" parquet:"paused"`
+	EvaluationSkipped      bool      `json:"evaluation_skipped" parquet:"evaluation_skipped"`
+	ExtendedDays           int64     `json:"extended_days" parquet:"extended_days"`
+	ExcludedEntries        int64     `json:"excluded_entries" parquet:"excluded_entries"`
+	DegradedSources        []string  `json:"degraded_sources" parquet:"degraded_sources,list"`
+}
+
+func NewExportRow(ctx context.Context, report *model.AnalysisReport) ExportRow {
+	runID, _ := appctx.GetRunID(ctx)
+	row := ExportRow{
+		ReportDate:           ReportDate(report.GeneratedAt),
+		GoalType:             report.Goal.String(),
+		RunID:                runID,
+		GeneratedAt:          report.GeneratedAt,
+		IsGoalAchieved:       report.IsGoalAchieved,
+		EarnedPoints:         int64(report.EarnedPoints),
+		TargetPoints:         int64(report.TargetPoints),
+		IsPointsGoalAchieved: report.IsPointsGoalAchieved,
+		Entries:              int64(report.Metrics.Entries),
+		Characters:           int64(report.Metrics.Characters),
+		ReadingTimeMinutes:   int64(report.Metrics.ReadingTimeMinutes),
+		CodeBlocks:           int64(report.Metrics.CodeBlocks),
+		Images:               int64(report.Metrics.Images),
+		Headings:             int64(report.Metrics.Headings),
+		Paused:               report.Paused,
+		EvaluationSkipped:    report.EvaluationSkipped,
+		ExtendedDays:         int64(report.ExtendedDays),
+		ExcludedEntries:      int64(len(report.ExcludedEntries)),
+		DegradedSources:      lo.Ternary(report.DegradedSources == nil, []string{}, report.DegradedSources),
+	}
+	if entry, ok := report.LatestEntry.Get(); ok {
+		row.LatestEntryTitle = entry.Title
+		row.LatestEntryPlatform = entry.Platform.Name
+		row.LatestEntryPublishedAt = entry.PublishedAt
+	}
+	return row
+}
+
+// ExportKey は dt と goal で Hive 形式にパーティション分割したキーを返す。
+// NOTE: 同じ日の再実行では同じキーに上書きされる
+func ExportKey(goal model.GoalType, now time.Time, format string) string {
+	return path.Join(ExportDatePrefix(now), "goal="+goal.String(), "data."+format)
+}
+
+// ExportDatePrefix は now の JST の日付のパーティションの prefix を返す。
+func ExportDatePrefix(now time.Time) string {
+	return path.Join(exportPrefix, "dt="+ReportDate(now)) + "/"
+}
+
+// CompactedExportKey は from から to までを 1 つにまとめた Parquet のキーを返す。
+// NOTE: パーティション分割したデータと同じテーブルで読まれないよう、別の prefix に保存する
+func CompactedExportKey(from, to time.Time) string {
+	return path.Join(compactedExportPrefix, fmt.Sprintf("%s_%s.parquet", ReportDate(from), ReportDate(to)))
+}
+
+func ExportFormat(key string) string {
+	return strings.TrimPrefix(path.Ext(key), ".")
+}
+
+func EncodeExportRows(rows []ExportRow, format string) ([]byte, error) {
+	switch format {
+	case ExportFormatNDJSON:
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		for _, row := range rows {
+			if err := encoder.Encode(row); err != nil {
+				return nil, err
+			}
+		}
+		return buf.Bytes(), nil
+	case ExportFormatParquet:
+		var buf bytes.Buffer
+		if err := parquet.Write(&buf, rows); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+func DecodeExportRows(b []byte, format string) ([]ExportRow, error) {
+	switch format {
+	case ExportFormatNDJSON:
+		var rows []ExportRow
+		scanner := bufio.NewScanner(bytes.NewReader(b))
+		scanner.Buffer(nil, 1024*1024)
+		for scanner.Scan() {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			var row ExportRow
+			if err := json.Unmarshal(scanner.Bytes(), &row); err != nil {
+				return nil, err
+			}
+			rows = append(rows, row)
+		}
+		return rows, scanner.Err()
+	case ExportFormatParquet:
+		return parquet.Read[ExportRow](bytes.NewReader(b), int64(len(b)))
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// SortExportRows は日付、目標の順に並べる。
+func SortExportRows(rows []ExportRow) {
+	slices.SortFunc(rows, func(a, b ExportRow) int {
+		return cmp.Or(strings.Compare(a.ReportDate, b.ReportDate), strings.Compare(a.GoalType, b.GoalType))
+	})
+}
@@ -0,0 +1,112 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewExportRow(t *testing.T) {
+	report := &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentWeek,
+		GeneratedAt:    time.Date(2025, 1, 9, 15, 0, 0, 0, time.UTC),
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints: 2,
+		TargetPoints: 3,
+		Metrics: model.ContentMetricsSummary{
+			Entries: 2,
+			ContentMetrics: model.ContentMetrics{
+				Characters:         1200,
+				ReadingTimeMinutes: 3,
+				CodeBlocks:         1,
+			},
+		},
+		ExcludedEntries: []*model.ExcludedEntry{{Entry: &model.Entry{}, Reason: model.ExclusionReasonTooShort}},
+	}
+
+	got := NewExportRow(appctx.SetRunID(context.Background(), "run-1"), report)
+	assert.Equal(t, ExportRow{
+		// NOTE: 日付は JST で決まる
+		ReportDate:             "2025-01-10",
+		GoalType:               "recent_week",
+		RunID:                  "run-1",
+		GeneratedAt:            time.Date(2025, 1, 9, 15, 0, 0, 0, time.UTC),
+		IsGoalAchieved:         true,
+		LatestEntryTitle:       "Go 言語の slice について",
+		LatestEntryPlatform:    "Zenn",
+		LatestEntryPublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+		EarnedPoints:           2,
+		TargetPoints:           3,
+		Entries:                2,
+		Characters:             1200,
+		ReadingTimeMinutes:     3,
+		CodeBlocks:             1,
+		ExcludedEntries:        1,
+		DegradedSources:        []string{},
+	}, got)
+}
+
+func TestEncodeDecodeExportRows(t *testing.T) {
+	rows := []ExportRow{
+		{
+			ReportDate:      "2025-01-10",
+			GoalType:        "recent_week",
+			RunID:           "run-1",
+			GeneratedAt:     time.Date(2025, 1, 10, 14, 59, 59, 0, time.UTC),
+			IsGoalAchieved:  true,
+			EarnedPoints:    2,
+			DegradedSources: []string{"hatena"},
+		},
+		{
+			ReportDate:      "2025-01-11",
+			GoalType:        "recent_week",
+			GeneratedAt:     time.Date(2025, 1, 11, 14, 59, 59, 0, time.UTC),
+			DegradedSources: []string{},
+		},
+	}
+
+	tests := []string{ExportFormatNDJSON, ExportFormatParquet}
+	for _, format := range tests {
+		t.Run(format, func(t *testing.T) {
+			b, err := EncodeExportRows(rows, format)
+			require.NoError(t, err)
+
+			got, err := DecodeExportRows(b, format)
+			require.NoError(t, err)
+			require.Len(t, got, len(rows))
+			for i := range rows {
+				assert.True(t, rows[i].GeneratedAt.Equal(got[i].GeneratedAt))
+				got[i].GeneratedAt = rows[i].GeneratedAt
+				got[i].LatestEntryPublishedAt = rows[i].LatestEntryPublishedAt
+			}
+			assert.Equal(t, rows, got)
+		})
+	}
+}
+
+func TestEncodeExportRows_UnsupportedFormat(t *testing.T) {
+	_, err := EncodeExportRows(nil, "csv")
+	assert.Error(t, err)
+}
+
+func TestExportKey(t *testing.T) {
+	now := time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, "export/analysis_report/dt=2025-01-10/goal=recent_month/data.parquet", ExportKey(model.GoalTypeRecentMonth, now, ExportFormatParquet))
+	assert.Equal(t, "export/analysis_report/dt=2025-01-10/", ExportDatePrefix(now))
+	assert.Equal(t, ExportFormatParquet, ExportFormat(ExportKey(model.GoalTypeRecentMonth, now, ExportFormatParquet)))
+	assert.Equal(t, "export/analysis_report_compacted/2025-01-01_2025-01-31.parquet", CompactedExportKey(
+		time.Date(2024, 12, 31, 15, 0, 0, 0, time.UTC),
+		time.Date(2025, 1, 30, 15, 0, 0, 0, time.UTC),
+	))
+}
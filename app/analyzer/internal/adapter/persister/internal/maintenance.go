@@ -0,0 +1,297 @@
+package internal
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+const (
+	kindMonthlyAnalysisReport = "analysis_report_monthly"
+	archivePrefix             = "archive"
+)
+
+// ErrObjectNotFound は Storage にキーのオブジェクトが存在しないことを表す。
+var ErrObjectNotFound = errors.New("object not found")
+
+// Storage はレポートを保存する S3 やローカルのディレクトリを、キーで読み書きできるストレージとして扱う。
+type Storage interface {
+	// List は prefix から始まるキーを返す。
+	List(ctx context.Context, prefix string) ([]string, error)
+	// Get はオブジェクトが存在しない場合に ErrObjectNotFound を返す。
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, b []byte) error
+	Delete(ctx context.Context, key string) error
+}
+
+// MonthlyEnvelope は 1 か月分の日次のレポートをまとめた月次のファイルを表す。
+// NOTE: 日次のレポートは保存されたスキーマバージョンのまま保持する
+type MonthlyEnvelope struct {
+	SchemaVersion int               `json:"schema_version"`
+	Goal          string            `json:"goal"`
+	Month         string            `json:"month"`
+	Timezone      string            `json:"timezone"`
+	Reports       []json.RawMessage `json:"reports"`
+}
+
+// MonthlyAnalysisReportKey は now の JST の月の月次のファイルのキーを返す。
+func MonthlyAnalysisReportKey(goal model.GoalType, now time.Time) string {
+	return monthlyAnalysisReportKey(goal.String(), beginningOfMonth(now))
+}
+
+func monthlyAnalysisReportKey(goal string, month time.Time) string {
+	return path.Join(kindMonthlyAnalysisReport, goal, month.Format("2006/01"), "data.json")
+}
+
+func beginningOfMonth(t time.Time) time.Time {
+	t = t.In(lo.ToPtr(date.LocationJST()))
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
+
+// DecodeMonthlyAnalysisReports は月次のファイルに含まれるレポートを日付の古い順に返す。
+func DecodeMonthlyAnalysisReports(b []byte) ([]*model.AnalysisReport, error) {
+	var envelope MonthlyEnvelope
+	if err := json.Unmarshal(b, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.SchemaVersion != SchemaVersion {
+		return nil, fmt.Errorf("unsupported schema version: %d", envelope.SchemaVersion)
+	}
+	reports := make([]*model.AnalysisReport, 0, len(envelope.Reports))
+	for _, raw := range envelope.Reports {
+		report, err := DecodeAnalysisReport(raw)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+type reportObject struct {
+	key     string
+	date    time.Time
+	monthly bool
+}
+
+// parseReportKey はレポートのキーから日付を取り出す。月次のファイルの場合は月の初日を返す。
+func parseReportKey(key string) (reportObject, bool) {
+	jst := lo.ToPtr(date.LocationJST())
+	parts := strings.Split(key, "/")
+	parse := func(layout string, loc *time.Location, parts ...string) (reportObject, bool) {
+		d, err := time.ParseInLocation(layout, strings.Join(parts, "/"), loc)
+		return reportObject{key: key, date: d}, err == nil
+	}
+
+	switch {
+	// analysis_report/<goal>/YYYY/MM/DD/data.json, digest_report/<goal>/YYYY/MM/DD/data.json
+	case len(parts) == 6 && (parts[0] == kindAnalysisReport || parts[0] == kindDigestReport):
+		return parse("2006/01/02", jst, parts[2:5]...)
+	// NOTE: スキーマバージョン 1 のレポートは analysis_report/YYYY/MM/DD/hh/mm/ss/data.json に実行環境の時刻で保存されている
+	case len(parts) == 8 && parts[0] == kindAnalysisReport:
+		return parse("2006/01/02", time.UTC, parts[1:4]...)
+	// analysis_report_monthly/<goal>/YYYY/MM/data.json
+	case len(parts) == 5 && parts[0] == kindMonthlyAnalysisReport:
+		object, ok := parse("2006/01", jst, parts[2:4]...)
+		object.monthly = true
+		return object, ok
+	default:
+		return reportObject{}, false
+	}
+}
+
+// Maintain は保持期間を過ぎたレポートを削除またはアーカイブしてから、前月以前の日次のレポートを月次のファイルにまとめる。
+func Maintain(ctx context.Context, storage Storage, policy *persister.MaintenancePolicy) (*persister.MaintenanceResult, error) {
+	result := &persister.MaintenanceResult{}
+	if !policy.RetentionCutoff.IsZero() {
+		if err := applyRetention(ctx, storage, policy, result); err != nil {
+			return nil, err
+		}
+	}
+	if err := compactMonthly(ctx, storage, policy, result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func applyRetention(ctx context.Context, storage Storage, policy *persister.MaintenancePolicy, result *persister.MaintenanceResult) error {
+	var objects []reportObject
+	for _, prefix := range []string{kindAnalysisReport + "/", kindDigestReport + "/", kindMonthlyAnalysisReport + "/"} {
+		keys, err := storage.List(ctx, prefix)
+		if err != nil {
+			return err
+		}
+		for _, key := range keys {
+			if object, ok := parseReportKey(key); ok {
+				objects = append(objects, object)
+			}
+		}
+	}
+
+	for _, object := range objects {
+		// NOTE: 月次のファイルは月末の日付が保持期間を過ぎた場合のみ対象とする
+		last := object.date
+		if object.monthly {
+			last = object.date.AddDate(0, 1, -1)
+		}
+		if !last.Before(policy.RetentionCutoff) {
+			continue
+		}
+
+		if policy.Archive {
+			result.Archived++
+		} else {
+			result.Deleted++
+		}
+		if policy.DryRun {
+			continue
+		}
+
+		if policy.Archive {
+			b, err := storage.Get(ctx, object.key)
+			if err != nil {
+				return err
+			}
+			if err := storage.Put(ctx, path.Join(archivePrefix, object.key), b); err != nil {
+				return err
+			}
+		}
+		if err := storage.Delete(ctx, object.key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type monthlyGroup struct {
+	goal    string
+	month   time.Time
+	keys    []string
+	reports map[string]json.RawMessage
+}
+
+// compactMonthly は前月以前の日次のレポートを目標と月ごとに 1 つのファイルにまとめる。
+// NOTE: 同じ日のレポートが複数ある場合は最後に生成されたものを残す。既存の月次のファイルがある場合は日次のレポートで上書きして更新する
+func compactMonthly(ctx context.Context, storage Storage, policy *persister.MaintenancePolicy, result *persister.MaintenanceResult) error {
+	keys, err := storage.List(ctx, kindAnalysisReport+"/")
+	if err != nil {
+		return err
+	}
+	currentMonth := beginningOfMonth(policy.Now)
+
+	groups := map[string]*monthlyGroup{}
+	generatedAt := map[string]time.Time{}
+	for _, key := range keys {
+		object, ok := parseReportKey(key)
+		if !ok || !object.date.Before(currentMonth) {
+			continue
+		}
+		// NOTE: ドライランでは保持期間を過ぎたレポートが残っているため、まとめる対象から除く
+		if !policy.RetentionCutoff.IsZero() && object.date.Before(policy.RetentionCutoff) {
+			continue
+		}
+
+		b, err := storage.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		report, err := DecodeAnalysisReport(b)
+		if err != nil {
+			return fmt.Errorf("failed to decode analysis report %s: %w", key, err)
+		}
+
+		// NOTE: スキーマバージョン 1 のキーの日付は JST の日付とずれることがあるため、生成日時で当月かを判定し直す
+		month := beginningOfMonth(report.GeneratedAt)
+		if !month.Before(currentMonth) {
+			continue
+		}
+		monthlyKey := monthlyAnalysisReportKey(report.Goal.String(), month)
+		group, ok := groups[monthlyKey]
+		if !ok {
+			group = &monthlyGroup{goal: report.Goal.String(), month: month, reports: map[string]json.RawMessage{}}
+			groups[monthlyKey] = group
+		}
+		group.keys = append(group.keys, key)
+
+		day := monthlyKey + ":" + ReportDate(report.GeneratedAt)
+		if latest, ok := generatedAt[day]; !ok || report.GeneratedAt.After(latest) {
+			generatedAt[day] = report.GeneratedAt
+			group.reports[ReportDate(report.GeneratedAt)] = b
+		}
+	}
+
+	for _, monthlyKey := range slices.Sorted(maps.Keys(groups)) {
+		group := groups[monthlyKey]
+		result.Compacted += len(group.keys)
+		result.MonthlyFiles++
+		if policy.DryRun {
+			continue
+		}
+
+		reports, err := mergeMonthlyReports(ctx, storage, monthlyKey, group.reports)
+		if err != nil {
+			return err
+		}
+		b, err := json.Marshal(&MonthlyEnvelope{
+			SchemaVersion: SchemaVersion,
+			Goal:          group.goal,
+			Month:         group.month.Format("2006-01"),
+			Timezone:      timezone,
+			Reports:       reports,
+		})
+		if err != nil {
+			return err
+		}
+		if err := storage.Put(ctx, monthlyKey, b); err != nil {
+			return err
+		}
+		for _, key := range group.keys {
+			if err := storage.Delete(ctx, key); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// mergeMonthlyReports は既存の月次のファイルのレポートに日次のレポートを上書きし、日付の古い順に返す。
+func mergeMonthlyReports(ctx context.Context, storage Storage, monthlyKey string, daily map[string]json.RawMessage) ([]json.RawMessage, error) {
+	merged := map[string]json.RawMessage{}
+
+	b, err := storage.Get(ctx, monthlyKey)
+	switch {
+	case errors.Is(err, ErrObjectNotFound):
+	case err != nil:
+		return nil, err
+	default:
+		var envelope MonthlyEnvelope
+		if err := json.Unmarshal(b, &envelope); err != nil {
+			return nil, fmt.Errorf("failed to decode monthly analysis report %s: %w", monthlyKey, err)
+		}
+		for _, raw := range envelope.Reports {
+			report, err := DecodeAnalysisReport(raw)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode monthly analysis report %s: %w", monthlyKey, err)
+			}
+			merged[ReportDate(report.GeneratedAt)] = raw
+		}
+	}
+	maps.Copy(merged, daily)
+
+	dates := slices.SortedFunc(maps.Keys(merged), cmp.Compare[string])
+	return lo.Map(dates, func(d string, _ int) json.RawMessage {
+		return merged[d]
+	}), nil
+}
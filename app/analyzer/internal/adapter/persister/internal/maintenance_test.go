@@ -0,0 +1,173 @@
+package internal
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStorage map[string][]byte
+
+func (s memoryStorage) List(_ context.Context, prefix string) ([]string, error) {
+	return slices.Sorted(func(yield func(string) bool) {
+		for key := range maps.Keys(s) {
+			if strings.HasPrefix(key, prefix) && !yield(key) {
+				return
+			}
+		}
+	}), nil
+}
+
+func (s memoryStorage) Get(_ context.Context, key string) ([]byte, error) {
+	b, ok := s[key]
+	if !ok {
+		return nil, ErrObjectNotFound
+	}
+	return b, nil
+}
+
+func (s memoryStorage) Put(_ context.Context, key string, b []byte) error {
+	s[key] = b
+	return nil
+}
+
+func (s memoryStorage) Delete(_ context.Context, key string) error {
+	delete(s, key)
+	return nil
+}
+
+func putAnalysisReport(t *testing.T, storage memoryStorage, goal model.GoalType, generatedAt time.Time, earnedPoints int) {
+	t.Helper()
+	b, err := EncodeAnalysisReport(context.Background(), &model.AnalysisReport{Goal: goal, GeneratedAt: generatedAt, EarnedPoints: earnedPoints})
+	require.NoError(t, err)
+	storage[AnalysisReportKey(goal, generatedAt)] = b
+}
+
+func TestMaintain_Compaction(t *testing.T) {
+	ctx := context.Background()
+	jst := lo.ToPtr(date.LocationJST())
+	storage := memoryStorage{}
+
+	putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 1, 1, 9, 0, 0, 0, jst), 1)
+	putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 1, 31, 9, 0, 0, 0, jst), 2)
+	putAnalysisReport(t, storage, model.GoalTypeRecentMonth, time.Date(2025, 1, 15, 9, 0, 0, 0, jst), 3)
+	// NOTE: 当月のレポートはまとめない
+	putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 2, 1, 9, 0, 0, 0, jst), 4)
+	// NOTE: スキーマバージョン 1 のレポートは同じ日の最後に生成されたものを残す
+	storage["analysis_report/2024/12/10/00/00/00/data.json"] = []byte(`{"goal": 1, "generated_at": "2024-12-10T00:00:00Z", "earned_points": 5}`)
+	storage["analysis_report/2024/12/10/01/00/00/data.json"] = []byte(`{"goal": 1, "generated_at": "2024-12-10T01:00:00Z", "earned_points": 6}`)
+
+	got, err := Maintain(ctx, storage, &persister.MaintenancePolicy{Now: time.Date(2025, 2, 10, 9, 0, 0, 0, jst)})
+	require.NoError(t, err)
+	assert.Equal(t, &persister.MaintenanceResult{Compacted: 5, MonthlyFiles: 3}, got)
+
+	keys, err := storage.List(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"analysis_report/recent_week/2025/02/01/data.json",
+		"analysis_report_monthly/recent_month/2025/01/data.json",
+		"analysis_report_monthly/recent_week/2024/12/data.json",
+		"analysis_report_monthly/recent_week/2025/01/data.json",
+	}, keys)
+
+	reports, err := DecodeMonthlyAnalysisReports(storage["analysis_report_monthly/recent_week/2025/01/data.json"])
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, lo.Map(reports, func(r *model.AnalysisReport, _ int) int { return r.EarnedPoints }))
+
+	reports, err = DecodeMonthlyAnalysisReports(storage["analysis_report_monthly/recent_week/2024/12/data.json"])
+	require.NoError(t, err)
+	assert.Equal(t, []int{6}, lo.Map(reports, func(r *model.AnalysisReport, _ int) int { return r.EarnedPoints }))
+
+	// NOTE: 既存の月次のファイルに後から保存された日次のレポートをまとめる
+	putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 1, 15, 9, 0, 0, 0, jst), 7)
+	putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 1, 31, 10, 0, 0, 0, jst), 8)
+	got, err = Maintain(ctx, storage, &persister.MaintenancePolicy{Now: time.Date(2025, 2, 10, 9, 0, 0, 0, jst)})
+	require.NoError(t, err)
+	assert.Equal(t, &persister.MaintenanceResult{Compacted: 2, MonthlyFiles: 1}, got)
+
+	reports, err = DecodeMonthlyAnalysisReports(storage["analysis_report_monthly/recent_week/2025/01/data.json"])
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 7, 8}, lo.Map(reports, func(r *model.AnalysisReport, _ int) int { return r.EarnedPoints }))
+}
+
+func TestMaintain_Retention(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, jst)
+
+	newStorage := func(t *testing.T) memoryStorage {
+		storage := memoryStorage{}
+		putAnalysisReport(t, storage, model.GoalTypeRecentWeek, time.Date(2025, 3, 1, 9, 0, 0, 0, jst), 1)
+		storage["digest_report/week/2025/02/14/data.json"] = []byte(`{}`)
+		storage["digest_report/week/2025/02/15/data.json"] = []byte(`{}`)
+		storage["analysis_report_monthly/recent_week/2025/01/data.json"] = []byte(`{}`)
+		// NOTE: 月末が保持期間内の月次のファイルは残す
+		storage["analysis_report_monthly/recent_week/2025/02/data.json"] = []byte(`{}`)
+		return storage
+	}
+
+	tests := []struct {
+		name       string
+		policy     *persister.MaintenancePolicy
+		wantResult *persister.MaintenanceResult
+		wantKeys   []string
+	}{
+		{
+			name:       "delete",
+			policy:     &persister.MaintenancePolicy{Now: now, RetentionCutoff: time.Date(2025, 2, 15, 0, 0, 0, 0, jst)},
+			wantResult: &persister.MaintenanceResult{Deleted: 2},
+			wantKeys: []string{
+				"analysis_report/recent_week/2025/03/01/data.json",
+				"analysis_report_monthly/recent_week/2025/02/data.json",
+				"digest_report/week/2025/02/15/data.json",
+			},
+		},
+		{
+			name:       "archive",
+			policy:     &persister.MaintenancePolicy{Now: now, RetentionCutoff: time.Date(2025, 2, 15, 0, 0, 0, 0, jst), Archive: true},
+			wantResult: &persister.MaintenanceResult{Archived: 2},
+			wantKeys: []string{
+				"analysis_report/recent_week/2025/03/01/data.json",
+				"analysis_report_monthly/recent_week/2025/02/data.json",
+				"archive/analysis_report_monthly/recent_week/2025/01/data.json",
+				"archive/digest_report/week/2025/02/14/data.json",
+				"digest_report/week/2025/02/15/data.json",
+			},
+		},
+		{
+			name:       "dry run",
+			policy:     &persister.MaintenancePolicy{Now: now, RetentionCutoff: time.Date(2025, 2, 15, 0, 0, 0, 0, jst), DryRun: true},
+			wantResult: &persister.MaintenanceResult{Deleted: 2},
+			wantKeys: []string{
+				"analysis_report/recent_week/2025/03/01/data.json",
+				"analysis_report_monthly/recent_week/2025/01/data.json",
+				"analysis_report_monthly/recent_week/2025/02/data.json",
+				"digest_report/week/2025/02/14/data.json",
+				"digest_report/week/2025/02/15/data.json",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			storage := newStorage(t)
+
+			got, err := Maintain(ctx, storage, tt.policy)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResult, got)
+
+			keys, err := storage.List(ctx, "")
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantKeys, keys)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// NOTE: 複数のプロセスが同時に起動してもマイグレーションが重複して適用されないよう、advisory lock で排他する
+const migrationLockID = 49919201
+
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+type migration struct {
+	version int
+	name    string
+	sql     string
+}
+
+// migrate は未適用のマイグレーションをバージョン順に適用する。
+// NOTE: マイグレーションはファイル名の先頭の数値をバージョンとし、一度適用したファイルは変更しない
+func migrate(ctx context.Context, pool *pgxpool.Pool) error {
+	conn, err := pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_lock($1)`, migrationLockID); err != nil {
+		return err
+	}
+	defer func() {
+		_, _ = conn.Exec(context.WithoutCancel(ctx), `SELECT pg_advisory_unlock($1)`, migrationLockID)
+	}()
+
+	if _, err := conn.Exec(ctx, `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version INTEGER PRIMARY KEY,
+    applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+)`); err != nil {
+		return err
+	}
+
+	all, err := loadMigrations()
+	if err != nil {
+		return err
+	}
+	for _, m := range all {
+		if err := apply(ctx, conn.Conn(), m); err != nil {
+			return fmt.Errorf("failed to apply migration %s: %w", m.name, err)
+		}
+	}
+	return nil
+}
+
+func loadMigrations() ([]migration, error) {
+	names, err := fs.Glob(migrations, "migrations/*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	all := make([]migration, 0, len(names))
+	for _, name := range names {
+		prefix, _, _ := strings.Cut(path.Base(name), "_")
+		version, err := strconv.Atoi(prefix)
+		if err != nil {
+			return nil, fmt.Errorf("invalid migration name %s: %w", name, err)
+		}
+		b, err := migrations.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, migration{version: version, name: path.Base(name), sql: string(b)})
+	}
+	slices.SortFunc(all, func(a, b migration) int {
+		return a.version - b.version
+	})
+	return all, nil
+}
+
+func apply(ctx context.Context, conn *pgx.Conn, m migration) error {
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	var applied bool
+	if err := tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)`, m.version).Scan(&applied); err != nil {
+		return err
+	}
+	if applied {
+		return nil
+	}
+
+	if _, err := tx.Exec(ctx, m.sql); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `INSERT INTO schema_migrations (version) VALUES ($1)`, m.version); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
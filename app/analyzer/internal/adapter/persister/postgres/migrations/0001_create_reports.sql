@@ -0,0 +1,42 @@
+CREATE TABLE analysis_reports (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    goal TEXT NOT NULL,
+    -- JST の日付
+    report_date DATE NOT NULL,
+    run_id TEXT NOT NULL,
+    generated_at TIMESTAMPTZ NOT NULL,
+    is_goal_achieved BOOLEAN NOT NULL,
+    earned_points INTEGER NOT NULL,
+    target_points INTEGER NOT NULL,
+    is_points_goal_achieved BOOLEAN NOT NULL,
+    paused BOOLEAN NOT NULL,
+    evaluation_skipped BOOLEAN NOT NULL,
+    extended_days INTEGER NOT NULL,
+    -- S3 と同じエンベロープ形式の JSON
+    report JSONB NOT NULL,
+    UNIQUE (goal, report_date)
+);
+
+CREATE TABLE analysis_report_entries (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    report_id BIGINT NOT NULL REFERENCES analysis_reports (id) ON DELETE CASCADE,
+    -- latest: 最新エントリ, excluded: 評価対象外としたエントリ
+    role TEXT NOT NULL,
+    title TEXT NOT NULL,
+    platform TEXT NOT NULL,
+    published_at TIMESTAMPTZ NOT NULL,
+    exclusion_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX analysis_report_entries_report_id ON analysis_report_entries (report_id);
+
+CREATE TABLE digest_reports (
+    id BIGINT GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+    goal TEXT NOT NULL,
+    report_date DATE NOT NULL,
+    run_id TEXT NOT NULL,
+    period_start TIMESTAMPTZ NOT NULL,
+    period_end TIMESTAMPTZ NOT NULL,
+    report JSONB NOT NULL,
+    UNIQUE (goal, report_date)
+);
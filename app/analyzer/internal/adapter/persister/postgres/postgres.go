@@ -0,0 +1,201 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+const (
+	entryRoleLatest   = "latest"
+	entryRoleExcluded = "excluded"
+)
+
+// Open はコネクションプールを作成し、未適用のマイグレーションを適用する。
+func Open(ctx context.Context, dsn string) (*pgxpool.Pool, error) {
+	pool, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(ctx, pool); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
+
+func NewPersistAnalysisReport(pool *pgxpool.Pool) persister.PersistAnalysisReport {
+	return func(ctx context.Context, report *model.AnalysisReport) error {
+		return persistAnalysisReport(ctx, pool, report)
+	}
+}
+
+func NewFindAnalysisReport(pool *pgxpool.Pool) persister.FindAnalysisReport {
+	return func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+		return findAnalysisReport(ctx, pool, goal, now)
+	}
+}
+
+func NewListAnalysisReports(pool *pgxpool.Pool) persister.ListAnalysisReports {
+	return func(ctx context.Context, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport] {
+		return listAnalysisReports(ctx, pool, goal, from, to)
+	}
+}
+
+func NewPersistDigestReport(pool *pgxpool.Pool) persister.PersistDigestReport {
+	return func(ctx context.Context, report *model.DigestReport) error {
+		return persistDigestReport(ctx, pool, report)
+	}
+}
+
+// persistAnalysisReport は目標と日付ごとに 1 件のレポートを保存する。
+// NOTE: S3 と同様に、同じ日の再実行では既存のレポートとエントリを置き換える
+func persistAnalysisReport(ctx context.Context, pool *pgxpool.Pool, report *model.AnalysisReport) error {
+	b, err := internal.EncodeAnalysisReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	runID, _ := appctx.GetRunID(ctx)
+
+	return pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		var reportID int64
+		if err := tx.QueryRow(ctx, `INSERT INTO analysis_reports (
+    goal, report_date, run_id, generated_at, is_goal_achieved, earned_points, target_points,
+    is_points_goal_achieved, paused, evaluation_skipped, extended_days, report
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+ON CONFLICT (goal, report_date) DO UPDATE SET
+    run_id = excluded.run_id,
+    generated_at = excluded.generated_at,
+    is_goal_achieved = excluded.is_goal_achieved,
+    earned_points = excluded.earned_points,
+    target_points = excluded.target_points,
+    is_points_goal_achieved = excluded.is_points_goal_achieved,
+    paused = excluded.paused,
+    evaluation_skipped = excluded.evaluation_skipped,
+    extended_days = excluded.extended_days,
+    report = excluded.report
+RETURNING id`,
+			report.Goal.String(),
+			internal.ReportDate(report.GeneratedAt),
+			runID,
+			report.GeneratedAt,
+			report.IsGoalAchieved,
+			report.EarnedPoints,
+			report.TargetPoints,
+			report.IsPointsGoalAchieved,
+			report.Paused,
+			report.EvaluationSkipped,
+			report.ExtendedDays,
+			string(b),
+		).Scan(&reportID); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM analysis_report_entries WHERE report_id = $1`, reportID); err != nil {
+			return err
+		}
+
+		batch := &pgx.Batch{}
+		if entry, ok := report.LatestEntry.Get(); ok {
+			queueEntry(batch, reportID, entryRoleLatest, entry, "")
+		}
+		for _, excluded := range report.ExcludedEntries {
+			queueEntry(batch, reportID, entryRoleExcluded, excluded.Entry, string(excluded.Reason))
+		}
+		if batch.Len() == 0 {
+			return nil
+		}
+		return tx.SendBatch(ctx, batch).Close()
+	})
+}
+
+func queueEntry(batch *pgx.Batch, reportID int64, role string, entry *model.Entry, reason string) {
+	batch.Queue(`INSERT INTO analysis_report_entries (
+    report_id, role, title, platform, published_at, exclusion_reason
+) VALUES ($1, $2, $3, $4, $5, $6)`,
+		reportID, role, entry.Title, entry.Platform.Name, entry.PublishedAt, reason,
+	)
+}
+
+func findAnalysisReport(ctx context.Context, pool *pgxpool.Pool, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+	var b []byte
+	err := pool.QueryRow(ctx, `SELECT report FROM analysis_reports WHERE goal = $1 AND report_date = $2`,
+		goal.String(), internal.ReportDate(now),
+	).Scan(&b)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return mo.Ok(mo.None[*model.AnalysisReport]())
+	}
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+
+	report, err := internal.DecodeAnalysisReport(b)
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+	return mo.Ok(mo.Some(report))
+}
+
+func listAnalysisReports(ctx context.Context, pool *pgxpool.Pool, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport] {
+	rows, err := pool.Query(ctx, `SELECT report_date::text, report FROM analysis_reports
+WHERE goal = $1 AND report_date BETWEEN $2 AND $3
+ORDER BY report_date`,
+		goal.String(), internal.ReportDate(from), internal.ReportDate(to),
+	)
+	if err != nil {
+		return mo.Err[[]*model.AnalysisReport](err)
+	}
+	defer rows.Close()
+
+	var reports []*model.AnalysisReport
+	for rows.Next() {
+		var reportDate string
+		var b []byte
+		if err := rows.Scan(&reportDate, &b); err != nil {
+			return mo.Err[[]*model.AnalysisReport](err)
+		}
+		report, err := internal.DecodeAnalysisReport(b)
+		if err != nil {
+			return mo.Err[[]*model.AnalysisReport](fmt.Errorf("failed to decode analysis report of %s: %w", reportDate, err))
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return mo.Err[[]*model.AnalysisReport](err)
+	}
+	return mo.Ok(reports)
+}
+
+func persistDigestReport(ctx context.Context, pool *pgxpool.Pool, report *model.DigestReport) error {
+	b, err := internal.EncodeDigestReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	runID, _ := appctx.GetRunID(ctx)
+
+	_, err = pool.Exec(ctx, `INSERT INTO digest_reports (
+    period, report_date, run_id, period_start, period_end, report
+) VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (period, report_date) DO UPDATE SET
+    run_id = excluded.run_id,
+    period_start = excluded.period_start,
+    period_end = excluded.period_end,
+    report = excluded.report`,
+		report.Period.String(),
+		internal.ReportDate(report.PeriodEnd),
+		runID,
+		report.PeriodStart,
+		report.PeriodEnd,
+		string(b),
+	)
+	return err
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// openTestPool はテストごとに作成したスキーマを使うコネクションプールを返す。
+// NOTE: POSTGRES_TEST_DSN が未設定の場合はスキップする。compose.yaml の postgres サービスを起動して実行する
+func openTestPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("POSTGRES_TEST_DSN")
+	if dsn == "" {
+		t.Skip("POSTGRES_TEST_DSN is not set")
+	}
+
+	ctx := context.Background()
+	schema := "keeput_test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	conn, err := pgx.Connect(ctx, dsn)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close(ctx) }()
+	_, err = conn.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		conn, err := pgx.Connect(context.Background(), dsn)
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close(context.Background()) }()
+		_, _ = conn.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	pool, err := Open(ctx, withSearchPath(dsn, schema))
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+func withSearchPath(dsn, schema string) string {
+	if !strings.Contains(dsn, "://") {
+		return fmt.Sprintf("%s search_path=%s", dsn, schema)
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&search_path=" + schema
+	}
+	return dsn + "?search_path=" + schema
+}
+
+func newReport(generatedAt time.Time, earnedPoints int) *model.AnalysisReport {
+	return &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentWeek,
+		GeneratedAt:    generatedAt,
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints: earnedPoints,
+		ExcludedEntries: []*model.ExcludedEntry{
+			{
+				Entry: &model.Entry{
+					Title:       "メモ",
+					PublishedAt: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformHatena(),
+				},
+				Reason: model.ExclusionReasonTooShort,
+			},
+		},
+	}
+}
+
+func TestAnalysisReport(t *testing.T) {
+	pool := openTestPool(t)
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+
+	persist := NewPersistAnalysisReport(pool)
+	find := NewFindAnalysisReport(pool)
+	list := NewListAnalysisReports(pool)
+
+	// NOTE: 2025-01-10 と 2025-01-11 (JST) のレポート
+	require.NoError(t, persist(ctx, newReport(time.Date(2025, 1, 10, 14, 59, 59, 0, time.UTC), 1)))
+	require.NoError(t, persist(ctx, newReport(time.Date(2025, 1, 11, 14, 59, 59, 0, time.UTC), 2)))
+
+	// NOTE: 同じ日の再実行では上書きされる
+	rerun := newReport(time.Date(2025, 1, 11, 14, 0, 0, 0, time.UTC), 3)
+	require.NoError(t, persist(ctx, rerun))
+
+	got, err := find(ctx, model.GoalTypeRecentWeek, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.Equal(t, 3, got.MustGet().EarnedPoints)
+	assert.Equal(t, rerun.ExcludedEntries, got.MustGet().ExcludedEntries)
+
+	notFound, err := find(ctx, model.GoalTypeRecentMonth, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	assert.True(t, notFound.IsAbsent())
+
+	history, err := list(ctx, model.GoalTypeRecentWeek, time.Date(2025, 1, 9, 15, 0, 0, 0, time.UTC), time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].EarnedPoints)
+	assert.Equal(t, 3, history[1].EarnedPoints)
+
+	var reports, entries int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM analysis_reports`).Scan(&reports))
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM analysis_report_entries`).Scan(&entries))
+	assert.Equal(t, 2, reports)
+	assert.Equal(t, 4, entries)
+}
+
+func TestMigrate_Idempotent(t *testing.T) {
+	pool := openTestPool(t)
+	ctx := context.Background()
+
+	require.NoError(t, migrate(ctx, pool))
+
+	var migrations int
+	require.NoError(t, pool.QueryRow(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&migrations))
+	assert.Equal(t, 2, migrations)
+}
+
+func TestPersistDigestReport(t *testing.T) {
+	pool := openTestPool(t)
+	ctx := context.Background()
+
+	report := &model.DigestReport{
+		Period:      model.DigestPeriodMonth,
+		PeriodStart: time.Date(2024, 12, 15, 15, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	persist := NewPersistDigestReport(pool)
+	require.NoError(t, persist(ctx, report))
+	require.NoError(t, persist(ctx, report))
+
+	var period, reportDate string
+	require.NoError(t, pool.QueryRow(ctx, `SELECT period, report_date::text FROM digest_reports`).Scan(&period, &reportDate))
+	assert.Equal(t, "month", period)
+	assert.Equal(t, "2025-01-15", reportDate)
+}
@@ -0,0 +1,130 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+func NewCompactAnalysisReportExports(config aws.Config) persister.CompactAnalysisReportExports {
+	initS3Client(config)
+	return compactAnalysisReportExports
+}
+
+func exportAnalysisReport(ctx context.Context, report *model.AnalysisReport) error {
+	rows := []internal.ExportRow{internal.NewExportRow(ctx, report)}
+	for _, format := range config.ExportFormats() {
+		b, err := internal.EncodeExportRows(rows, format)
+		if err != nil {
+			return err
+		}
+		if err := put(ctx, internal.ExportKey(report.Goal, report.GeneratedAt, format), b, exportContentType(format)); err != nil {
+			return fmt.Errorf("failed to export analysis report as %s: %w", format, err)
+		}
+	}
+	return nil
+}
+
+func exportContentType(format string) string {
+	return lo.Ternary(format == internal.ExportFormatParquet, contentTypeParquet, contentTypeNDJSON)
+}
+
+// compactAnalysisReportExports は from から to までの各日のパーティションのレポートを 1 つの Parquet にまとめる。
+// NOTE: 同じパーティションに NDJSON と Parquet の両方がある場合は、行が重複しないよう NDJSON のみを読む
+func compactAnalysisReportExports(ctx context.Context, from, to time.Time) mo.Result[*persister.CompactedExport] {
+	bucket := config.S3BucketName()
+
+	var rows []internal.ExportRow
+	for day := from; !day.After(to); day = date.AddDays(day, 1) {
+		keys, err := listKeys(ctx, bucket, internal.ExportDatePrefix(day))
+		if err != nil {
+			return mo.Err[*persister.CompactedExport](err)
+		}
+
+		partitions := lo.GroupBy(keys, path.Dir)
+		for _, dir := range lo.Keys(partitions) {
+			key, ok := lo.Find(partitions[dir], func(key string) bool {
+				return internal.ExportFormat(key) == internal.ExportFormatNDJSON
+			})
+			if !ok {
+				key, ok = lo.Find(partitions[dir], func(key string) bool {
+					return internal.ExportFormat(key) == internal.ExportFormatParquet
+				})
+			}
+			if !ok {
+				continue
+			}
+
+			partitionRows, err := getExportRows(ctx, bucket, key)
+			if err != nil {
+				return mo.Err[*persister.CompactedExport](err)
+			}
+			rows = append(rows, partitionRows...)
+		}
+	}
+	if len(rows) == 0 {
+		return mo.Err[*persister.CompactedExport](fmt.Errorf("no exported analysis report found from %s to %s", from.Format(time.DateOnly), to.Format(time.DateOnly)))
+	}
+	internal.SortExportRows(rows)
+
+	b, err := internal.EncodeExportRows(rows, internal.ExportFormatParquet)
+	if err != nil {
+		return mo.Err[*persister.CompactedExport](err)
+	}
+	key := internal.CompactedExportKey(from, to)
+	if err := put(ctx, key, b, contentTypeParquet); err != nil {
+		return mo.Err[*persister.CompactedExport](err)
+	}
+	return mo.Ok(&persister.CompactedExport{Key: key, Rows: len(rows)})
+}
+
+func listKeys(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(prefix),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, object := range page.Contents {
+			keys = append(keys, aws.ToString(object.Key))
+		}
+	}
+	return keys, nil
+}
+
+func getExportRows(ctx context.Context, bucket, key string) ([]internal.ExportRow, error) {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := internal.DecodeExportRows(b, internal.ExportFormat(key))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode exported analysis report %s: %w", key, err)
+	}
+	return rows, nil
+}
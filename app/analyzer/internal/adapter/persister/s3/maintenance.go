@@ -0,0 +1,69 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+func NewMaintainReports(config aws.Config) persister.MaintainReports {
+	initS3Client(config)
+	return maintainReports
+}
+
+func maintainReports(ctx context.Context, policy *persister.MaintenancePolicy) mo.Result[*persister.MaintenanceResult] {
+	result, err := internal.Maintain(ctx, storage{}, policy)
+	if err != nil {
+		return mo.Err[*persister.MaintenanceResult](err)
+	}
+	return mo.Ok(result)
+}
+
+// storage はバケットを internal.Storage として扱う。
+type storage struct{}
+
+func (storage) List(ctx context.Context, prefix string) ([]string, error) {
+	return listKeys(ctx, config.S3BucketName(), prefix)
+}
+
+func (storage) Get(ctx context.Context, key string) ([]byte, error) {
+	return getObject(ctx, config.S3BucketName(), key)
+}
+
+func (storage) Put(ctx context.Context, key string, b []byte) error {
+	return put(ctx, key, b, contentTypeJSON)
+}
+
+func (storage) Delete(ctx context.Context, key string) error {
+	_, err := s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(config.S3BucketName()),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+// getObject はオブジェクトが存在しない場合に internal.ErrObjectNotFound を返す。
+func getObject(ctx context.Context, bucket, key string) ([]byte, error) {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, internal.ErrObjectNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
@@ -3,25 +3,37 @@ package s3
 import (
 	"bytes"
 	"context"
-	"encoding/json"
-	"path"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
-	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/internal/s3client"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
 	"github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
 )
 
+const (
+	contentTypeJSON    = "application/json"
+	contentTypeNDJSON  = "application/x-ndjson"
+	contentTypeParquet = "application/vnd.apache.parquet"
+)
+
 var s3ClientOnce sync.Once
 var s3Client *s3.Client
 
 func initS3Client(config aws.Config) {
 	s3ClientOnce.Do(func() {
-		s3Client = s3.NewFromConfig(config)
+		s3Client = s3client.New(config)
 	})
 }
 
@@ -30,25 +42,176 @@ func NewPersistAnalysisReport(config aws.Config) persister.PersistAnalysisReport
 	return persistAnalysisReport
 }
 
+func NewFindAnalysisReport(config aws.Config) persister.FindAnalysisReport {
+	initS3Client(config)
+	return findAnalysisReport
+}
+
+func NewListAnalysisReports(config aws.Config) persister.ListAnalysisReports {
+	initS3Client(config)
+	return listAnalysisReports
+}
+
+func NewPersistDigestReport(config aws.Config) persister.PersistDigestReport {
+	initS3Client(config)
+	return persistDigestReport
+}
+
+// persistAnalysisReport はレポートを保存し、書き出し形式が設定されている場合は分析用の形式でも書き出す。
+// NOTE: 書き出しは集計用の副次的なデータのため、失敗してもレポートの保存は成功とする
 func persistAnalysisReport(ctx context.Context, report *model.AnalysisReport) error {
-	b, err := json.Marshal(report)
+	b, err := internal.EncodeAnalysisReport(ctx, report)
 	if err != nil {
 		return err
 	}
+	if err := put(ctx, internal.AnalysisReportKey(report.Goal, report.GeneratedAt), b, contentTypeJSON); err != nil {
+		return err
+	}
+	if err := exportAnalysisReport(ctx, report); err != nil {
+		slog.Warn("failed to export analysis report",
+			slog.String("goal", report.Goal.String()),
+			slog.Time("generated_at", report.GeneratedAt),
+			slog.String("error", err.Error()),
+		)
+	}
+	return nil
+}
 
+// findAnalysisReport は目標と日付から決まるキーのレポートを返す。
+// NOTE: スキーマバージョン 1 のレポートは実行日時から決まるキーに保存されているため、見つからない場合は同じ日付の prefix 配下から探す。
+// それでも見つからない場合は月次のファイルにまとめられたレポートから探す
+func findAnalysisReport(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
 	bucket := config.S3BucketName()
-	now := appctx.GetNowOr(ctx, time.Now())
-	key := path.Join("analysis_report", now.Format("2006/01/02/15/04/05"), "data.json")
 
+	report, err := getAnalysisReport(ctx, bucket, internal.AnalysisReportKey(goal, now))
+	if err == nil {
+		return mo.Ok(mo.Some(report))
+	}
+	var noSuchKey *types.NoSuchKey
+	if !errors.As(err, &noSuchKey) {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+
+	var found mo.Option[*model.AnalysisReport]
+	paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+		Bucket: aws.String(bucket),
+		Prefix: aws.String(internal.LegacyAnalysisReportPrefix(now)),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return mo.Err[mo.Option[*model.AnalysisReport]](err)
+		}
+		for _, object := range page.Contents {
+			report, err := getAnalysisReport(ctx, bucket, aws.ToString(object.Key))
+			if err != nil {
+				return mo.Err[mo.Option[*model.AnalysisReport]](err)
+			}
+			if !matchesLegacyGoal(report, goal) {
+				continue
+			}
+			if latest, ok := found.Get(); !ok || report.GeneratedAt.After(latest.GeneratedAt) {
+				found = mo.Some(report)
+			}
+		}
+	}
+	if found.IsPresent() {
+		return mo.Ok(found)
+	}
+	return findMonthlyAnalysisReport(ctx, bucket, goal, now)
+}
+
+// matchesLegacyGoal はスキーマバージョン 1 のレポートが目標のものかどうかを返す。
+// NOTE: 目標ごとに保存する前のレポートは goal を持たず、既定の目標で分析されたものとみなす
+func matchesLegacyGoal(report *model.AnalysisReport, goal model.GoalType) bool {
+	if report.Goal == 0 {
+		return goal == model.GoalTypeRecentWeek
+	}
+	return report.Goal == goal
+}
+
+func findMonthlyAnalysisReport(ctx context.Context, bucket string, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+	key := internal.MonthlyAnalysisReportKey(goal, now)
+	b, err := getObject(ctx, bucket, key)
+	if errors.Is(err, internal.ErrObjectNotFound) {
+		return mo.Ok(mo.None[*model.AnalysisReport]())
+	}
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+
+	reports, err := internal.DecodeMonthlyAnalysisReports(b)
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](fmt.Errorf("failed to decode monthly analysis report %s: %w", key, err))
+	}
+	for _, report := range reports {
+		if internal.ReportDate(report.GeneratedAt) == internal.ReportDate(now) {
+			return mo.Ok(mo.Some(report))
+		}
+	}
+	return mo.Ok(mo.None[*model.AnalysisReport]())
+}
+
+// listAnalysisReports は from から to までの各日のレポートを取得する。
+// NOTE: キーが日付ごとに分かれているため、日ごとに取得する
+func listAnalysisReports(ctx context.Context, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport] {
+	var reports []*model.AnalysisReport
+	for day := from; !day.After(to); day = date.AddDays(day, 1) {
+		report, err := findAnalysisReport(ctx, goal, day).Get()
+		if err != nil {
+			return mo.Err[[]*model.AnalysisReport](err)
+		}
+		if report, ok := report.Get(); ok {
+			reports = append(reports, report)
+		}
+	}
+	return mo.Ok(reports)
+}
+
+func getAnalysisReport(ctx context.Context, bucket, key string) (*model.AnalysisReport, error) {
+	out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer out.Body.Close()
+
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, err
+	}
+	report, err := internal.DecodeAnalysisReport(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode analysis report %s: %w", key, err)
+	}
+	return report, nil
+}
+
+func persistDigestReport(ctx context.Context, report *model.DigestReport) error {
+	b, err := internal.EncodeDigestReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	return put(ctx, internal.DigestReportKey(report.Period, report.PeriodEnd), b, contentTypeJSON)
+}
+
+func put(ctx context.Context, key string, b []byte, contentType string) error {
+	bucket := config.S3BucketName()
 	if _, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
 		Bucket:      aws.String(bucket),
 		Key:         aws.String(key),
 		Body:        bytes.NewReader(b),
-		ContentType: aws.String("application/json"),
+		ContentType: aws.String(contentType),
 	}); err != nil {
 		return err
 	}
 
+	// NOTE: S3 は書き込み直後の読み取りの整合性が保証されているため、待機しない設定にするとレイテンシを抑えられる
+	if !config.S3WaitForObject() {
+		return nil
+	}
 	return s3.NewObjectExistsWaiter(s3Client).Wait(
 		ctx,
 		&s3.HeadObjectInput{
@@ -0,0 +1,236 @@
+package s3
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/johannesboyne/gofakes3"
+	"github.com/johannesboyne/gofakes3/backend/s3mem"
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBucket = "keeput-test"
+
+// NOTE: 書き出しの失敗を再現するため、このパーティションへの書き込みは失敗させる
+const failingExportPrefix = "export/analysis_report/dt=2025-05-01/"
+
+type failingExportBackend struct {
+	gofakes3.Backend
+}
+
+func (b *failingExportBackend) PutObject(bucketName, key string, meta map[string]string, input io.Reader, size int64, conditions *gofakes3.PutConditions) (gofakes3.PutObjectResult, error) {
+	if strings.HasPrefix(key, failingExportPrefix) {
+		return gofakes3.PutObjectResult{}, gofakes3.ErrInternal
+	}
+	return b.Backend.PutObject(bucketName, key, meta, input, size, conditions)
+}
+
+// NOTE: 設定値は初回の参照時に固定されるため、インプロセスの S3 互換サーバーを起動してから環境変数を設定する
+func TestMain(m *testing.M) {
+	backend := s3mem.New()
+	if err := backend.CreateBucket(testBucket); err != nil {
+		panic(err)
+	}
+	server := httptest.NewServer(gofakes3.New(&failingExportBackend{Backend: backend}).Server())
+	defer server.Close()
+
+	for key, value := range map[string]string{
+		"S3_BUCKET_NAME":     testBucket,
+		"S3_ENDPOINT":        server.URL,
+		"S3_USE_PATH_STYLE":  "true",
+		"S3_REGION":          "ap-northeast-1",
+		"S3_WAIT_FOR_OBJECT": "false",
+		"EXPORT_FORMATS":     "ndjson,parquet",
+	} {
+		if err := os.Setenv(key, value); err != nil {
+			panic(err)
+		}
+	}
+
+	initS3Client(aws.Config{
+		Credentials: credentials.NewStaticCredentialsProvider("test", "test", ""),
+	})
+	os.Exit(m.Run())
+}
+
+func newReport(generatedAt time.Time, earnedPoints int) *model.AnalysisReport {
+	return &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentWeek,
+		GeneratedAt:    generatedAt,
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints: earnedPoints,
+	}
+}
+
+func TestAnalysisReport(t *testing.T) {
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+	jst := lo.ToPtr(date.LocationJST())
+
+	require.NoError(t, persistAnalysisReport(ctx, newReport(time.Date(2025, 2, 10, 23, 59, 59, 0, jst), 1)))
+	require.NoError(t, persistAnalysisReport(ctx, newReport(time.Date(2025, 2, 11, 23, 59, 59, 0, jst), 2)))
+	// NOTE: 同じ日の再実行では上書きされる
+	require.NoError(t, persistAnalysisReport(ctx, newReport(time.Date(2025, 2, 11, 23, 0, 0, 0, jst), 3)))
+
+	got, err := findAnalysisReport(ctx, model.GoalTypeRecentWeek, time.Date(2025, 2, 11, 9, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.Equal(t, 3, got.MustGet().EarnedPoints)
+
+	notFound, err := findAnalysisReport(ctx, model.GoalTypeRecentMonth, time.Date(2025, 2, 11, 9, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	assert.True(t, notFound.IsAbsent())
+
+	history, err := listAnalysisReports(ctx, model.GoalTypeRecentWeek, time.Date(2025, 2, 9, 0, 0, 0, 0, jst), time.Date(2025, 2, 11, 0, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].EarnedPoints)
+	assert.Equal(t, 3, history[1].EarnedPoints)
+}
+
+func TestPersistAnalysisReport_ExportFailed(t *testing.T) {
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+	jst := lo.ToPtr(date.LocationJST())
+	report := newReport(time.Date(2025, 5, 1, 23, 59, 59, 0, jst), 4)
+
+	require.NoError(t, persistAnalysisReport(ctx, report))
+
+	got, err := findAnalysisReport(ctx, model.GoalTypeRecentWeek, report.GeneratedAt).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.Equal(t, 4, got.MustGet().EarnedPoints)
+
+	keys, err := listKeys(ctx, testBucket, failingExportPrefix)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestFindAnalysisReport_Legacy(t *testing.T) {
+	ctx := context.Background()
+	// NOTE: スキーマバージョン 1 のレポートは実行日時から決まるキーに保存されている
+	legacy := []byte(`{"goal": 1, "generated_at": "2025-03-01T00:00:00Z", "is_goal_achieved": true, "earned_points": 5}`)
+	require.NoError(t, put(ctx, "analysis_report/2025/03/01/00/00/00/data.json", legacy, contentTypeJSON))
+
+	got, err := findAnalysisReport(ctx, model.GoalTypeRecentWeek, time.Date(2025, 3, 1, 1, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.Equal(t, 5, got.MustGet().EarnedPoints)
+	assert.True(t, got.MustGet().IsGoalAchieved)
+}
+
+// NOTE: 目標ごとに保存する前のレポートは goal と generated_at を持たない
+func TestFindAnalysisReport_LegacyWithoutGoal(t *testing.T) {
+	ctx := context.Background()
+	legacy := []byte(`{"is_goal_achieved": true, "latest_entry": {"Title": "Go 言語の slice について", "PublishedAt": "2025-03-02T10:00:00Z"}}`)
+	require.NoError(t, put(ctx, "analysis_report/2025/03/03/00/00/00/data.json", legacy, contentTypeJSON))
+
+	now := time.Date(2025, 3, 3, 1, 0, 0, 0, time.UTC)
+	got, err := findAnalysisReport(ctx, model.GoalTypeRecentWeek, now).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.True(t, got.MustGet().IsGoalAchieved)
+	assert.Equal(t, "Go 言語の slice について", got.MustGet().LatestEntry.MustGet().Title)
+
+	notFound, err := findAnalysisReport(ctx, model.GoalTypeRecentMonth, now).Get()
+	require.NoError(t, err)
+	assert.True(t, notFound.IsAbsent())
+}
+
+func TestCompactAnalysisReportExports(t *testing.T) {
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+	jst := lo.ToPtr(date.LocationJST())
+
+	for day := 1; day <= 3; day++ {
+		report := newReport(time.Date(2025, 4, day, 23, 59, 59, 0, jst), day)
+		require.NoError(t, persistAnalysisReport(ctx, report))
+		monthly := newReport(time.Date(2025, 4, day, 23, 59, 59, 0, jst), day*10)
+		monthly.Goal = model.GoalTypeRecentMonth
+		require.NoError(t, persistAnalysisReport(ctx, monthly))
+	}
+
+	keys, err := listKeys(ctx, testBucket, "export/analysis_report/dt=2025-04-01/")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"export/analysis_report/dt=2025-04-01/goal=recent_month/data.ndjson",
+		"export/analysis_report/dt=2025-04-01/goal=recent_month/data.parquet",
+		"export/analysis_report/dt=2025-04-01/goal=recent_week/data.ndjson",
+		"export/analysis_report/dt=2025-04-01/goal=recent_week/data.parquet",
+	}, keys)
+
+	got, err := compactAnalysisReportExports(ctx, time.Date(2025, 4, 1, 0, 0, 0, 0, jst), time.Date(2025, 4, 2, 0, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	assert.Equal(t, &persister.CompactedExport{Key: "export/analysis_report_compacted/2025-04-01_2025-04-02.parquet", Rows: 4}, got)
+
+	rows, err := getExportRows(ctx, testBucket, got.Key)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2025-04-01", "2025-04-01", "2025-04-02", "2025-04-02"}, lo.Map(rows, func(row internal.ExportRow, _ int) string {
+		return row.ReportDate
+	}))
+	assert.Equal(t, []int64{10, 1, 20, 2}, lo.Map(rows, func(row internal.ExportRow, _ int) int64 {
+		return row.EarnedPoints
+	}))
+}
+
+func TestPersistDigestReport(t *testing.T) {
+	ctx := context.Background()
+	report := &model.DigestReport{
+		Period:      model.DigestPeriodMonth,
+		PeriodStart: time.Date(2024, 12, 15, 15, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, persistDigestReport(ctx, report))
+
+	_, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+		Bucket: aws.String(testBucket),
+		Key:    aws.String("digest_report/month/2025/01/15/data.json"),
+	})
+	assert.NoError(t, err)
+}
+
+func TestMaintainReports(t *testing.T) {
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+	jst := lo.ToPtr(date.LocationJST())
+
+	// NOTE: 他のテストのレポートをまとめないよう、それより前の月のレポートを使う
+	require.NoError(t, persistAnalysisReport(ctx, newReport(time.Date(2024, 12, 1, 9, 0, 0, 0, jst), 1)))
+	require.NoError(t, persistAnalysisReport(ctx, newReport(time.Date(2024, 12, 2, 9, 0, 0, 0, jst), 2)))
+
+	got, err := NewMaintainReports(aws.Config{})(ctx, &persister.MaintenancePolicy{Now: time.Date(2025, 1, 10, 9, 0, 0, 0, jst)}).Get()
+	require.NoError(t, err)
+	assert.Equal(t, &persister.MaintenanceResult{Compacted: 2, MonthlyFiles: 1}, got)
+
+	keys, err := listKeys(ctx, testBucket, "analysis_report/recent_week/2024/")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	// NOTE: 月次のファイルにまとめた後も日付から取得できる
+	found, err := findAnalysisReport(ctx, model.GoalTypeRecentWeek, time.Date(2024, 12, 2, 0, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	require.True(t, found.IsPresent())
+	assert.Equal(t, 2, found.MustGet().EarnedPoints)
+
+	history, err := listAnalysisReports(ctx, model.GoalTypeRecentWeek, time.Date(2024, 12, 1, 0, 0, 0, 0, jst), time.Date(2024, 12, 3, 0, 0, 0, 0, jst)).Get()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].EarnedPoints)
+}
@@ -0,0 +1,42 @@
+CREATE TABLE analysis_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal TEXT NOT NULL,
+    -- JST の日付 (YYYY-MM-DD)
+    report_date TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    generated_at TEXT NOT NULL,
+    is_goal_achieved INTEGER NOT NULL,
+    earned_points INTEGER NOT NULL,
+    target_points INTEGER NOT NULL,
+    is_points_goal_achieved INTEGER NOT NULL,
+    paused INTEGER NOT NULL,
+    evaluation_skipped INTEGER NOT NULL,
+    extended_days INTEGER NOT NULL,
+    -- S3 と同じエンベロープ形式の JSON
+    report TEXT NOT NULL,
+    UNIQUE (goal, report_date)
+);
+
+CREATE TABLE analysis_report_entries (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    report_id INTEGER NOT NULL REFERENCES analysis_reports (id) ON DELETE CASCADE,
+    -- latest: 最新エントリ, excluded: 評価対象外としたエントリ
+    role TEXT NOT NULL,
+    title TEXT NOT NULL,
+    platform TEXT NOT NULL,
+    published_at TEXT NOT NULL,
+    exclusion_reason TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX analysis_report_entries_report_id ON analysis_report_entries (report_id);
+
+CREATE TABLE digest_reports (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    goal TEXT NOT NULL,
+    report_date TEXT NOT NULL,
+    run_id TEXT NOT NULL,
+    period_start TEXT NOT NULL,
+    period_end TEXT NOT NULL,
+    report TEXT NOT NULL,
+    UNIQUE (goal, report_date)
+);
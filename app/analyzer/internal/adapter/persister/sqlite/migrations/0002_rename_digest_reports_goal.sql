@@ -0,0 +1,8 @@
+-- ダイジェストは目標ではなく集計期間 (week / month) で保存する
+UPDATE digest_reports SET goal = CASE goal
+    WHEN 'recent_week' THEN 'week'
+    WHEN 'recent_month' THEN 'month'
+    ELSE goal
+END;
+
+ALTER TABLE digest_reports RENAME COLUMN goal TO period;
@@ -0,0 +1,212 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+
+	_ "modernc.org/sqlite"
+)
+
+const (
+	entryRoleLatest   = "latest"
+	entryRoleExcluded = "excluded"
+)
+
+// Open はデータベースファイルを開き、未適用のマイグレーションを適用する。
+// NOTE: CLI とサーバーなど複数のプロセスから同じファイルを使えるよう、WAL モードとし、ロック中は待機する
+func Open(ctx context.Context, path string) (*sql.DB, error) {
+	db, err := sql.Open("sqlite", fmt.Sprintf("file:%s?_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)&_pragma=busy_timeout(5000)", path))
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(ctx, db); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func NewPersistAnalysisReport(db *sql.DB) persister.PersistAnalysisReport {
+	return func(ctx context.Context, report *model.AnalysisReport) error {
+		return persistAnalysisReport(ctx, db, report)
+	}
+}
+
+func NewFindAnalysisReport(db *sql.DB) persister.FindAnalysisReport {
+	return func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+		return findAnalysisReport(ctx, db, goal, now)
+	}
+}
+
+func NewListAnalysisReports(db *sql.DB) persister.ListAnalysisReports {
+	return func(ctx context.Context, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport] {
+		return listAnalysisReports(ctx, db, goal, from, to)
+	}
+}
+
+func NewPersistDigestReport(db *sql.DB) persister.PersistDigestReport {
+	return func(ctx context.Context, report *model.DigestReport) error {
+		return persistDigestReport(ctx, db, report)
+	}
+}
+
+// persistAnalysisReport は目標と日付ごとに 1 件のレポートを保存する。
+// NOTE: S3 と同様に、同じ日の再実行では既存のレポートとエントリを置き換える
+func persistAnalysisReport(ctx context.Context, db *sql.DB, report *model.AnalysisReport) error {
+	b, err := internal.EncodeAnalysisReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	runID, _ := appctx.GetRunID(ctx)
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	var reportID int64
+	if err := tx.QueryRowContext(ctx, `INSERT INTO analysis_reports (
+    goal, report_date, run_id, generated_at, is_goal_achieved, earned_points, target_points,
+    is_points_goal_achieved, paused, evaluation_skipped, extended_days, report
+) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (goal, report_date) DO UPDATE SET
+    run_id = excluded.run_id,
+    generated_at = excluded.generated_at,
+    is_goal_achieved = excluded.is_goal_achieved,
+    earned_points = excluded.earned_points,
+    target_points = excluded.target_points,
+    is_points_goal_achieved = excluded.is_points_goal_achieved,
+    paused = excluded.paused,
+    evaluation_skipped = excluded.evaluation_skipped,
+    extended_days = excluded.extended_days,
+    report = excluded.report
+RETURNING id`,
+		report.Goal.String(),
+		internal.ReportDate(report.GeneratedAt),
+		runID,
+		formatTime(report.GeneratedAt),
+		report.IsGoalAchieved,
+		report.EarnedPoints,
+		report.TargetPoints,
+		report.IsPointsGoalAchieved,
+		report.Paused,
+		report.EvaluationSkipped,
+		report.ExtendedDays,
+		string(b),
+	).Scan(&reportID); err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM analysis_report_entries WHERE report_id = ?`, reportID); err != nil {
+		return err
+	}
+	if entry, ok := report.LatestEntry.Get(); ok {
+		if err := insertEntry(ctx, tx, reportID, entryRoleLatest, entry, ""); err != nil {
+			return err
+		}
+	}
+	for _, excluded := range report.ExcludedEntries {
+		if err := insertEntry(ctx, tx, reportID, entryRoleExcluded, excluded.Entry, string(excluded.Reason)); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func insertEntry(ctx context.Context, tx *sql.Tx, reportID int64, role string, entry *model.Entry, reason string) error {
+	_, err := tx.ExecContext(ctx, `INSERT INTO analysis_report_entries (
+    report_id, role, title, platform, published_at, exclusion_reason
+) VALUES (?, ?, ?, ?, ?, ?)`,
+		reportID, role, entry.Title, entry.Platform.Name, formatTime(entry.PublishedAt), reason,
+	)
+	return err
+}
+
+func findAnalysisReport(ctx context.Context, db *sql.DB, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+	var b string
+	err := db.QueryRowContext(ctx, `SELECT report FROM analysis_reports WHERE goal = ? AND report_date = ?`,
+		goal.String(), internal.ReportDate(now),
+	).Scan(&b)
+	if errors.Is(err, sql.ErrNoRows) {
+		return mo.Ok(mo.None[*model.AnalysisReport]())
+	}
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+
+	report, err := internal.DecodeAnalysisReport([]byte(b))
+	if err != nil {
+		return mo.Err[mo.Option[*model.AnalysisReport]](err)
+	}
+	return mo.Ok(mo.Some(report))
+}
+
+func listAnalysisReports(ctx context.Context, db *sql.DB, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport] {
+	rows, err := db.QueryContext(ctx, `SELECT report_date, report FROM analysis_reports
+WHERE goal = ? AND report_date BETWEEN ? AND ?
+ORDER BY report_date`,
+		goal.String(), internal.ReportDate(from), internal.ReportDate(to),
+	)
+	if err != nil {
+		return mo.Err[[]*model.AnalysisReport](err)
+	}
+	defer rows.Close()
+
+	var reports []*model.AnalysisReport
+	for rows.Next() {
+		var reportDate, b string
+		if err := rows.Scan(&reportDate, &b); err != nil {
+			return mo.Err[[]*model.AnalysisReport](err)
+		}
+		report, err := internal.DecodeAnalysisReport([]byte(b))
+		if err != nil {
+			return mo.Err[[]*model.AnalysisReport](fmt.Errorf("failed to decode analysis report of %s: %w", reportDate, err))
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return mo.Err[[]*model.AnalysisReport](err)
+	}
+	return mo.Ok(reports)
+}
+
+func persistDigestReport(ctx context.Context, db *sql.DB, report *model.DigestReport) error {
+	b, err := internal.EncodeDigestReport(ctx, report)
+	if err != nil {
+		return err
+	}
+	runID, _ := appctx.GetRunID(ctx)
+
+	_, err = db.ExecContext(ctx, `INSERT INTO digest_reports (
+    period, report_date, run_id, period_start, period_end, report
+) VALUES (?, ?, ?, ?, ?, ?)
+ON CONFLICT (period, report_date) DO UPDATE SET
+    run_id = excluded.run_id,
+    period_start = excluded.period_start,
+    period_end = excluded.period_end,
+    report = excluded.report`,
+		report.Period.String(),
+		internal.ReportDate(report.PeriodEnd),
+		runID,
+		formatTime(report.PeriodStart),
+		formatTime(report.PeriodEnd),
+		string(b),
+	)
+	return err
+}
+
+// NOTE: 実行環境のタイムゾーンによらず同じ値になるよう UTC に揃える
+func formatTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
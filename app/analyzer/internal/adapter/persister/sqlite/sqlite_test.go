@@ -0,0 +1,142 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReport(generatedAt time.Time, earnedPoints int) *model.AnalysisReport {
+	return &model.AnalysisReport{
+		Goal:           model.GoalTypeRecentWeek,
+		GeneratedAt:    generatedAt,
+		IsGoalAchieved: true,
+		LatestEntry: mo.Some(&model.Entry{
+			Title:       "Go 言語の slice について",
+			PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		}),
+		EarnedPoints: earnedPoints,
+		ExcludedEntries: []*model.ExcludedEntry{
+			{
+				Entry: &model.Entry{
+					Title:       "メモ",
+					PublishedAt: time.Date(2025, 1, 8, 10, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformHatena(),
+				},
+				Reason: model.ExclusionReasonTooShort,
+			},
+		},
+	}
+}
+
+func TestAnalysisReport(t *testing.T) {
+	ctx := appctx.SetRunID(context.Background(), "run-1")
+	path := filepath.Join(t.TempDir(), "keeput.db")
+	db, err := Open(ctx, path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	persist := NewPersistAnalysisReport(db)
+	find := NewFindAnalysisReport(db)
+	list := NewListAnalysisReports(db)
+
+	// NOTE: 2025-01-10 と 2025-01-11 (JST) のレポート
+	first := newReport(time.Date(2025, 1, 10, 14, 59, 59, 0, time.UTC), 1)
+	second := newReport(time.Date(2025, 1, 11, 14, 59, 59, 0, time.UTC), 2)
+	require.NoError(t, persist(ctx, first))
+	require.NoError(t, persist(ctx, second))
+
+	// NOTE: 同じ日の再実行では上書きされる
+	rerun := newReport(time.Date(2025, 1, 11, 15, 0, 0, 0, time.UTC).Add(-time.Minute), 3)
+	require.NoError(t, persist(ctx, rerun))
+
+	got, err := find(ctx, model.GoalTypeRecentWeek, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	require.True(t, got.IsPresent())
+	assert.Equal(t, 3, got.MustGet().EarnedPoints)
+	assert.Equal(t, rerun.ExcludedEntries, got.MustGet().ExcludedEntries)
+
+	notFound, err := find(ctx, model.GoalTypeRecentMonth, time.Date(2025, 1, 11, 0, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	assert.True(t, notFound.IsAbsent())
+
+	history, err := list(ctx, model.GoalTypeRecentWeek, time.Date(2025, 1, 9, 15, 0, 0, 0, time.UTC), time.Date(2025, 1, 10, 15, 0, 0, 0, time.UTC)).Get()
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, 1, history[0].EarnedPoints)
+	assert.Equal(t, 3, history[1].EarnedPoints)
+
+	var entries int
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT COUNT(*) FROM analysis_report_entries`).Scan(&entries))
+	assert.Equal(t, 4, entries)
+
+	// NOTE: 既存のファイルを開き直してもマイグレーションは再適用されない
+	reopened, err := Open(ctx, path)
+	require.NoError(t, err)
+	defer reopened.Close()
+	var migrations int
+	require.NoError(t, reopened.QueryRowContext(ctx, `SELECT COUNT(*) FROM schema_migrations`).Scan(&migrations))
+	assert.Equal(t, 2, migrations)
+}
+
+func TestPersistDigestReport(t *testing.T) {
+	ctx := context.Background()
+	db, err := Open(ctx, filepath.Join(t.TempDir(), "keeput.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	report := &model.DigestReport{
+		Period:      model.DigestPeriodMonth,
+		PeriodStart: time.Date(2024, 12, 15, 15, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+	}
+	persist := NewPersistDigestReport(db)
+	require.NoError(t, persist(ctx, report))
+	require.NoError(t, persist(ctx, report))
+
+	var period, reportDate string
+	require.NoError(t, db.QueryRowContext(ctx, `SELECT period, report_date FROM digest_reports`).Scan(&period, &reportDate))
+	assert.Equal(t, "month", period)
+	assert.Equal(t, "2025-01-15", reportDate)
+}
+
+// NOTE: 目標で保存していたダイジェストは、同じ長さの集計期間に読み替える
+func TestMigrate_DigestReportPeriod(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "keeput.db"))
+	require.NoError(t, err)
+	defer db.Close()
+
+	all, err := loadMigrations()
+	require.NoError(t, err)
+	_, err = db.ExecContext(ctx, `CREATE TABLE schema_migrations (version INTEGER PRIMARY KEY, applied_at TEXT NOT NULL)`)
+	require.NoError(t, err)
+	require.NoError(t, apply(ctx, db, all[0]))
+	_, err = db.ExecContext(ctx, `INSERT INTO digest_reports (goal, report_date, run_id, period_start, period_end, report) VALUES
+    ('recent_week', '2025-01-15', 'run-1', '', '', '{}'),
+    ('recent_month', '2025-01-15', 'run-1', '', '', '{}')`)
+	require.NoError(t, err)
+
+	require.NoError(t, migrate(ctx, db))
+
+	rows, err := db.QueryContext(ctx, `SELECT period FROM digest_reports ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+	var periods []string
+	for rows.Next() {
+		var period string
+		require.NoError(t, rows.Scan(&period))
+		periods = append(periods, period)
+	}
+	require.NoError(t, rows.Err())
+	assert.Equal(t, []string{"week", "month"}, periods)
+}
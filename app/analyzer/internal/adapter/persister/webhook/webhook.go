@@ -0,0 +1,54 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/apphttp"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+var httpClient = sync.OnceValue(func() *http.Client {
+	return apphttp.DefaultClient()
+})
+
+// NewPersistAnalysisReport は保存する形式のレポートを url に POST する。
+func NewPersistAnalysisReport(url string) persister.PersistAnalysisReport {
+	return func(ctx context.Context, report *model.AnalysisReport) error {
+		return persistAnalysisReport(ctx, url, report)
+	}
+}
+
+func persistAnalysisReport(ctx context.Context, url string, report *model.AnalysisReport) error {
+	b, err := internal.EncodeAnalysisReport(ctx, report)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	// NOTE: 受信側で同じ日の再実行を重複として扱えるよう、S3 と同じキーを渡す
+	req.Header.Set("Idempotency-Key", internal.AnalysisReportKey(report.Goal, report.GeneratedAt))
+
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to request with status code: %d; body: %s", resp.StatusCode, string(b))
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/internal"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersistAnalysisReport(t *testing.T) {
+	report := &model.AnalysisReport{
+		Goal:         model.GoalTypeRecentWeek,
+		GeneratedAt:  time.Date(2025, 1, 9, 15, 30, 0, 0, time.UTC),
+		EarnedPoints: 1,
+	}
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{"accepted", http.StatusAccepted, false},
+		{"rejected", http.StatusBadRequest, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *model.AnalysisReport
+			var idempotencyKey string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				got, err = internal.DecodeAnalysisReport(b)
+				require.NoError(t, err)
+				idempotencyKey = r.Header.Get("Idempotency-Key")
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := NewPersistAnalysisReport(server.URL)(context.Background(), report)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 1, got.EarnedPoints)
+			assert.Equal(t, "analysis_report/recent_week/2025/01/10/data.json", idempotencyKey)
+		})
+	}
+}
@@ -9,6 +9,7 @@ type key int
 
 const (
 	keyNow key = iota + 1
+	keyRunID
 )
 
 func SetNow(ctx context.Context, now time.Time) context.Context {
@@ -27,3 +28,13 @@ func GetNowOr(ctx context.Context, fallback time.Time) time.Time {
 	}
 	return now
 }
+
+// SetRunID は実行ごとに一意な ID を設定する。保存するレポートに記録し、ログやトレースとの突き合わせに使う。
+func SetRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, keyRunID, runID)
+}
+
+func GetRunID(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(keyRunID).(string)
+	return runID, ok
+}
@@ -1,11 +1,18 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
 	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/joho/godotenv"
+	"github.com/samber/lo"
 )
 
 func InitForLocal() error {
@@ -43,6 +50,248 @@ func FeedURLHatena() string {
 	return feedURLHatena()
 }
 
+const (
+	defaultFeedSourcePriority = 100
+)
+
+// FeedSource は FEED_SOURCES で設定される汎用フィードのフェッチ元。
+// ID はサーキットブレーカーやタイムアウト設定のキーとして使う。
+type FeedSource struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"display_name"`
+	URL         string `json:"url"`
+	Priority    int    `json:"priority"`
+}
+
+// NOTE: ID はエスケープせずに S3 のキーや環境変数名に使うため、使える文字を制限する
+var feedSourceIDPattern = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+var feedSources = sync.OnceValues(func() ([]FeedSource, error) {
+	return parseFeedSources(os.Getenv("FEED_SOURCES"))
+})
+
+func parseFeedSources(raw string) ([]FeedSource, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	var sources []FeedSource
+	if err := json.Unmarshal([]byte(raw), &sources); err != nil {
+		return nil, fmt.Errorf("failed to parse FEED_SOURCES: %w", err)
+	}
+	// NOTE: ID が重複するとサーキットブレーカーやタイムアウト設定を共有してしまうため、環境変数名に変換した後の重複も許可しない
+	seen := map[string]string{}
+	for i := range sources {
+		if sources[i].ID == "" || sources[i].URL == "" {
+			return nil, fmt.Errorf("FEED_SOURCES[%d]: id and url are required", i)
+		}
+		if !feedSourceIDPattern.MatchString(sources[i].ID) {
+			return nil, fmt.Errorf("FEED_SOURCES[%d]: id must match %s: %s", i, feedSourceIDPattern, sources[i].ID)
+		}
+		key := envKeySuffix(sources[i].ID)
+		if id, ok := seen[key]; ok {
+			return nil, fmt.Errorf("FEED_SOURCES[%d]: id %s conflicts with %s", i, sources[i].ID, id)
+		}
+		seen[key] = sources[i].ID
+		if sources[i].DisplayName == "" {
+			sources[i].DisplayName = sources[i].ID
+		}
+		// NOTE: 優先度が未指定の場合は既存プラットフォームより低い優先度とする
+		if sources[i].Priority == 0 {
+			sources[i].Priority = defaultFeedSourcePriority
+		}
+	}
+	return sources, nil
+}
+
+func FeedSources() ([]FeedSource, error) {
+	return feedSources()
+}
+
+var feedURLSpeakerDeck = sync.OnceValue(func() string {
+	return os.Getenv("FEED_URL_SPEAKERDECK")
+})
+
+func FeedURLSpeakerDeck() string {
+	return feedURLSpeakerDeck()
+}
+
+var feedURLDocswell = sync.OnceValue(func() string {
+	return os.Getenv("FEED_URL_DOCSWELL")
+})
+
+func FeedURLDocswell() string {
+	return feedURLDocswell()
+}
+
+const (
+	defaultSlideWeight = 3
+)
+
+var slideWeight = sync.OnceValue(func() int {
+	return intOr(os.Getenv("SLIDE_WEIGHT"), defaultSlideWeight)
+})
+
+// SlideWeight は登壇 1 件あたりの重みを返す。記事 1 件の重みは 1 とする。
+func SlideWeight() int {
+	return slideWeight()
+}
+
+// NOTE: 0 もしくは未設定の場合はポイント目標を評価しない
+var goalPoints = sync.OnceValue(func() int {
+	return intOr(os.Getenv("GOAL_POINTS"), 0)
+})
+
+func GoalPoints() int {
+	return goalPoints()
+}
+
+// NOTE: 0 もしくは未設定の場合は本文の長さによる除外を行わない
+var eligibilityMinCharacters = sync.OnceValue(func() int {
+	return intOr(os.Getenv("ELIGIBILITY_MIN_CHARACTERS"), 0)
+})
+
+func EligibilityMinCharacters() int {
+	return eligibilityMinCharacters()
+}
+
+// NOTE: カンマ区切りでタグを指定する
+var eligibilityExcludedTags = sync.OnceValue(func() []string {
+	return lo.Compact(lo.Map(strings.Split(os.Getenv("ELIGIBILITY_EXCLUDED_TAGS"), ","), func(s string, _ int) string {
+		return strings.TrimSpace(s)
+	}))
+})
+
+func EligibilityExcludedTags() []string {
+	return eligibilityExcludedTags()
+}
+
+var eligibilityExcludedTitlePattern = sync.OnceValues(func() (*regexp.Regexp, error) {
+	raw := os.Getenv("ELIGIBILITY_EXCLUDED_TITLE_PATTERN")
+	if raw == "" {
+		return nil, nil
+	}
+	pattern, err := regexp.Compile(raw)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse ELIGIBILITY_EXCLUDED_TITLE_PATTERN: %w", err)
+	}
+	return pattern, nil
+})
+
+// EligibilityExcludedTitlePattern はタイトルが一致するエントリを評価対象外とする正規表現を返す。未設定の場合は nil を返す。
+func EligibilityExcludedTitlePattern() (*regexp.Regexp, error) {
+	return eligibilityExcludedTitlePattern()
+}
+
+// PausePeriod は PAUSE_PERIODS で設定される休止期間。日付は YYYY-MM-DD 形式で指定する。
+type PausePeriod struct {
+	Start  string `json:"start"`
+	End    string `json:"end"`
+	Reason string `json:"reason"`
+}
+
+var pausePeriods = sync.OnceValues(func() ([]PausePeriod, error) {
+	raw := os.Getenv("PAUSE_PERIODS")
+	if raw == "" {
+		return nil, nil
+	}
+	var periods []PausePeriod
+	if err := json.Unmarshal([]byte(raw), &periods); err != nil {
+		return nil, fmt.Errorf("failed to parse PAUSE_PERIODS: %w", err)
+	}
+	return periods, nil
+})
+
+func PausePeriods() ([]PausePeriod, error) {
+	return pausePeriods()
+}
+
+const (
+	defaultPauseMode = "skip"
+)
+
+// NOTE: skip もしくは extend を指定する
+var pauseMode = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(os.Getenv("PAUSE_MODE"), defaultPauseMode)
+})
+
+func PauseMode() string {
+	return pauseMode()
+}
+
+var hatenaID = sync.OnceValue(func() string {
+	return os.Getenv("HATENA_ID")
+})
+
+func HatenaID() string {
+	return hatenaID()
+}
+
+var hatenaBlogID = sync.OnceValue(func() string {
+	return os.Getenv("HATENA_BLOG_ID")
+})
+
+// NOTE: 独自ドメインを設定している場合も、はてなブログの管理画面に表示されるブログ ID を指定する
+func HatenaBlogID() string {
+	return hatenaBlogID()
+}
+
+var hatenaAPIKey = sync.OnceValue(func() string {
+	return os.Getenv("HATENA_API_KEY")
+})
+
+func HatenaAPIKey() string {
+	return hatenaAPIKey()
+}
+
+// NOTE: basic もしくは wsse を指定する。未指定の場合は basic とする
+var hatenaAtomPubAuth = sync.OnceValue(func() string {
+	return strings.ToLower(os.Getenv("HATENA_ATOMPUB_AUTH"))
+})
+
+func HatenaAtomPubAuth() string {
+	return hatenaAtomPubAuth()
+}
+
+// AtomPub の認証情報が揃っている場合のみ AtomPub を使う
+func HatenaAtomPubEnabled() bool {
+	return hatenaID() != "" && hatenaBlogID() != "" && hatenaAPIKey() != ""
+}
+
+var markdownDir = sync.OnceValue(func() string {
+	return os.Getenv("MARKDOWN_DIR")
+})
+
+func MarkdownDir() string {
+	return markdownDir()
+}
+
+var gitRepositoryDir = sync.OnceValue(func() string {
+	return os.Getenv("GIT_REPOSITORY_DIR")
+})
+
+func GitRepositoryDir() string {
+	return gitRepositoryDir()
+}
+
+const (
+	defaultGitArticlePaths = "articles"
+)
+
+// NOTE: カンマ区切りでリポジトリルートからの相対パスを指定する
+var gitArticlePaths = sync.OnceValue(func() []string {
+	raw := os.Getenv("GIT_ARTICLE_PATHS")
+	if raw == "" {
+		raw = defaultGitArticlePaths
+	}
+	return lo.Compact(lo.Map(strings.Split(raw, ","), func(s string, _ int) string {
+		return strings.Trim(strings.TrimSpace(s), "/")
+	}))
+})
+
+func GitArticlePaths() []string {
+	return gitArticlePaths()
+}
+
 var logLevel = sync.OnceValue(func() string {
 	return os.Getenv("LOG_LEVEL")
 })
@@ -51,6 +300,85 @@ func LogLevel() string {
 	return logLevel()
 }
 
+const (
+	LockerCloudflareWorker = "cfworker"
+	LockerMemory           = "memory"
+	LockerFile             = "file"
+	LockerDynamoDB         = "dynamodb"
+	LockerRedis            = "redis"
+
+	defaultLockerTTL           = 30 * time.Second
+	defaultLockerFailurePolicy = "fail"
+	defaultLockerDynamoDBTable = "keeput-lock"
+	defaultLockerRedisURL      = "redis://localhost:6379/0"
+)
+
+// NOTE: ローカルの CLI や同じホストの cron から実行する場合は memory または file を指定し、Worker をデプロイせずに実行できるようにする
+var locker = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(strings.ToLower(os.Getenv("LOCKER")), LockerCloudflareWorker)
+})
+
+// Locker は重複実行を防ぐロックの実装を返す。
+func Locker() string {
+	return locker()
+}
+
+// NOTE: 実行中は TTL の 1/3 ごとにロックを延長するため、異常終了して解放されなかったロックも TTL を過ぎると取得できるようになる
+var lockerTTL = sync.OnceValue(func() time.Duration {
+	return durationOr(os.Getenv("LOCKER_TTL"), defaultLockerTTL)
+})
+
+// LockerTTL はロックの有効期間を返す。
+func LockerTTL() time.Duration {
+	return lockerTTL()
+}
+
+// NOTE: 既定では重複実行を防ぐことを優先し、ロックを取得できない場合は実行を失敗させる
+var lockerFailurePolicy = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(strings.ToLower(os.Getenv("LOCKER_FAILURE_POLICY")), defaultLockerFailurePolicy)
+})
+
+// LockerFailurePolicy は LOCKER のロックの取得でエラーになった場合の扱い (fail、proceed または fallback) を返す。
+func LockerFailurePolicy() string {
+	return lockerFailurePolicy()
+}
+
+var lockerFallback = sync.OnceValue(func() string {
+	return strings.ToLower(os.Getenv("LOCKER_FALLBACK"))
+})
+
+// LockerFallback は LOCKER_FAILURE_POLICY が fallback の場合に使うロックの実装を返す。
+func LockerFallback() string {
+	return lockerFallback()
+}
+
+var lockerFileDir = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(os.Getenv("LOCKER_FILE_DIR"), filepath.Join(os.TempDir(), "keeput-locks"))
+})
+
+// LockerFileDir は LOCKER が file の場合にロックファイルを作成するディレクトリを返す。
+func LockerFileDir() string {
+	return lockerFileDir()
+}
+
+var lockerDynamoDBTable = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(os.Getenv("LOCKER_DYNAMODB_TABLE"), defaultLockerDynamoDBTable)
+})
+
+// LockerDynamoDBTable は LOCKER が dynamodb の場合に使うテーブル名を返す。
+func LockerDynamoDBTable() string {
+	return lockerDynamoDBTable()
+}
+
+var lockerRedisURL = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(os.Getenv("LOCKER_REDIS_URL"), defaultLockerRedisURL)
+})
+
+// LockerRedisURL は LOCKER が redis の場合に接続する Redis の URL を返す。
+func LockerRedisURL() string {
+	return lockerRedisURL()
+}
+
 var lockerURLCloudflareWorker = sync.OnceValue(func() string {
 	return os.Getenv("LOCKER_URL_CLOUDFLARE_WORKER")
 })
@@ -82,3 +410,217 @@ var s3BucketName = sync.OnceValue(func() string {
 func S3BucketName() string {
 	return s3BucketName()
 }
+
+// NOTE: MinIO などの S3 互換のストレージを使う場合に設定する
+var s3Endpoint = sync.OnceValue(func() string {
+	return os.Getenv("S3_ENDPOINT")
+})
+
+func S3Endpoint() string {
+	return s3Endpoint()
+}
+
+var s3UsePathStyle = sync.OnceValue(func() bool {
+	return boolOr(os.Getenv("S3_USE_PATH_STYLE"), false)
+})
+
+// S3UsePathStyle はバケット名をホスト名ではなくパスに含めるかどうかを返す。
+func S3UsePathStyle() bool {
+	return s3UsePathStyle()
+}
+
+// NOTE: 未設定の場合は AWS SDK の設定 (AWS_REGION など) に従う
+var s3Region = sync.OnceValue(func() string {
+	return os.Getenv("S3_REGION")
+})
+
+func S3Region() string {
+	return s3Region()
+}
+
+var s3WaitForObject = sync.OnceValue(func() bool {
+	return boolOr(os.Getenv("S3_WAIT_FOR_OBJECT"), true)
+})
+
+// S3WaitForObject は保存したオブジェクトが取得できるようになるまで待機するかどうかを返す。
+func S3WaitForObject() bool {
+	return s3WaitForObject()
+}
+
+const (
+	PersisterS3       = "s3"
+	PersisterSQLite   = "sqlite"
+	PersisterPostgres = "postgres"
+
+	defaultSQLitePath = "keeput.db"
+)
+
+// NOTE: セルフホストの場合は sqlite または postgres を指定し、データベースにレポートを保存する
+var persister = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(strings.ToLower(os.Getenv("PERSISTER")), PersisterS3)
+})
+
+// Persister はレポートの保存先を返す。
+func Persister() string {
+	return persister()
+}
+
+// NOTE: 保存済みのレポートの有無で再試行時に通知するかを判断するため、既定では必須とする
+var persisterRequired = sync.OnceValue(func() bool {
+	return boolOr(os.Getenv("PERSISTER_REQUIRED"), true)
+})
+
+// PersisterRequired は PERSISTER の保存先に保存できなかった場合に実行を失敗させるかどうかを返す。
+func PersisterRequired() bool {
+	return persisterRequired()
+}
+
+var filePersisterDir = sync.OnceValue(func() string {
+	return os.Getenv("FILE_PERSISTER_DIR")
+})
+
+// FilePersisterDir はレポートを追加で保存するローカルのディレクトリを返す。未設定の場合は保存しない。
+func FilePersisterDir() string {
+	return filePersisterDir()
+}
+
+var filePersisterRequired = sync.OnceValue(func() bool {
+	return boolOr(os.Getenv("FILE_PERSISTER_REQUIRED"), false)
+})
+
+func FilePersisterRequired() bool {
+	return filePersisterRequired()
+}
+
+var webhookPersisterURL = sync.OnceValue(func() string {
+	return os.Getenv("WEBHOOK_PERSISTER_URL")
+})
+
+// WebhookPersisterURL はレポートをアーカイブとして POST する URL を返す。未設定の場合は送信しない。
+func WebhookPersisterURL() string {
+	return webhookPersisterURL()
+}
+
+var webhookPersisterRequired = sync.OnceValue(func() bool {
+	return boolOr(os.Getenv("WEBHOOK_PERSISTER_REQUIRED"), false)
+})
+
+func WebhookPersisterRequired() bool {
+	return webhookPersisterRequired()
+}
+
+var sqlitePath = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(os.Getenv("SQLITE_PATH"), defaultSQLitePath)
+})
+
+func SQLitePath() string {
+	return sqlitePath()
+}
+
+var postgresDSN = sync.OnceValue(func() string {
+	return os.Getenv("POSTGRES_DSN")
+})
+
+func PostgresDSN() string {
+	return postgresDSN()
+}
+
+// NOTE: カンマ区切りで ndjson, parquet を指定する。未設定の場合は書き出さない
+var exportFormats = sync.OnceValue(func() []string {
+	return lo.Compact(lo.Map(strings.Split(os.Getenv("EXPORT_FORMATS"), ","), func(s string, _ int) string {
+		return strings.ToLower(strings.TrimSpace(s))
+	}))
+})
+
+// ExportFormats は分析用に書き出すレポートの形式を返す。
+func ExportFormats() []string {
+	return exportFormats()
+}
+
+const (
+	RetentionModeArchive = "archive"
+	RetentionModeDelete  = "delete"
+)
+
+var retentionDays = sync.OnceValue(func() int {
+	return intOr(os.Getenv("RETENTION_DAYS"), 0)
+})
+
+// RetentionDays はレポートを保持する日数を返す。0 の場合は期限なく保持する。
+func RetentionDays() int {
+	return retentionDays()
+}
+
+// NOTE: 誤って設定した場合でも復元できるよう、既定では削除せずにアーカイブする
+var retentionMode = sync.OnceValue(func() string {
+	return lo.CoalesceOrEmpty(strings.ToLower(os.Getenv("RETENTION_MODE")), RetentionModeArchive)
+})
+
+// RetentionMode は保持期間を過ぎたレポートの扱い (archive または delete) を返す。
+func RetentionMode() string {
+	return retentionMode()
+}
+
+const (
+	defaultFetchTimeout                   = 10 * time.Second
+	defaultCircuitBreakerFailureThreshold = 3
+	defaultCircuitBreakerCooldown         = 6 * time.Hour
+)
+
+var fetchTimeout = sync.OnceValue(func() time.Duration {
+	return durationOr(os.Getenv("FETCH_TIMEOUT"), defaultFetchTimeout)
+})
+
+// NOTE: FETCH_TIMEOUT_<SOURCE> が未設定の場合は FETCH_TIMEOUT の値を使う
+func FetchTimeout(source string) time.Duration {
+	return durationOr(os.Getenv("FETCH_TIMEOUT_"+envKeySuffix(source)), fetchTimeout())
+}
+
+func envKeySuffix(s string) string {
+	return strings.Map(func(r rune) rune {
+		if ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') {
+			return r
+		}
+		return '_'
+	}, strings.ToUpper(s))
+}
+
+var circuitBreakerFailureThreshold = sync.OnceValue(func() int {
+	return intOr(os.Getenv("CIRCUIT_BREAKER_FAILURE_THRESHOLD"), defaultCircuitBreakerFailureThreshold)
+})
+
+func CircuitBreakerFailureThreshold() int {
+	return circuitBreakerFailureThreshold()
+}
+
+var circuitBreakerCooldown = sync.OnceValue(func() time.Duration {
+	return durationOr(os.Getenv("CIRCUIT_BREAKER_COOLDOWN"), defaultCircuitBreakerCooldown)
+})
+
+func CircuitBreakerCooldown() time.Duration {
+	return circuitBreakerCooldown()
+}
+
+func durationOr(s string, fallback time.Duration) time.Duration {
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+func intOr(s string, fallback int) int {
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return fallback
+	}
+	return i
+}
+
+func boolOr(s string, fallback bool) bool {
+	b, err := strconv.ParseBool(s)
+	if err != nil {
+		return fallback
+	}
+	return b
+}
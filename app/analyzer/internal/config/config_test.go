@@ -0,0 +1,64 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFeedSources(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []FeedSource
+		wantErr bool
+	}{
+		{
+			name: "fill defaults",
+			raw:  `[{"id": "tech-blog", "url": "https://example.com/feed"}]`,
+			want: []FeedSource{{ID: "tech-blog", DisplayName: "tech-blog", URL: "https://example.com/feed", Priority: defaultFeedSourcePriority}},
+		},
+		{
+			name: "not configured",
+			raw:  "",
+		},
+		{
+			name:    "id is required",
+			raw:     `[{"url": "https://example.com/feed"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "id must not contain path separator",
+			raw:     `[{"id": "../tech", "url": "https://example.com/feed"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "id must be lower case",
+			raw:     `[{"id": "Tech", "url": "https://example.com/feed"}]`,
+			wantErr: true,
+		},
+		{
+			name:    "duplicate id",
+			raw:     `[{"id": "tech", "url": "https://example.com/a"}, {"id": "tech", "url": "https://example.com/b"}]`,
+			wantErr: true,
+		},
+		{
+			// NOTE: FETCH_TIMEOUT_<ID> では - と _ を区別できない
+			name:    "id conflicts in env key",
+			raw:     `[{"id": "tech-blog", "url": "https://example.com/a"}, {"id": "tech_blog", "url": "https://example.com/b"}]`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFeedSources(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
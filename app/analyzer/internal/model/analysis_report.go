@@ -7,21 +7,81 @@ import (
 )
 
 type AnalysisReport struct {
+	Goal        GoalType  `json:"goal"`
+	GeneratedAt time.Time `json:"generated_at"`
+
 	IsGoalAchieved bool `json:"is_goal_achieved"`
 
 	LatestEntry mo.Option[*Entry] `json:"latest_entry"`
+
+	// 目標期間内に獲得したポイント
+	EarnedPoints int `json:"earned_points"`
+	// ポイント目標。0 の場合はポイント目標を評価しない
+	TargetPoints         int  `json:"target_points,omitempty"`
+	IsPointsGoalAchieved bool `json:"is_points_goal_achieved"`
+
+	// 目標期間内に公開されたエントリの本文のメトリクス
+	Metrics ContentMetricsSummary `json:"metrics"`
+
+	// 休止期間中の実行かどうか
+	Paused bool `json:"paused"`
+	// 休止モードが skip の場合は休止期間中に目標を評価せず、達成フラグは false とする
+	EvaluationSkipped bool `json:"evaluation_skipped,omitempty"`
+	// 休止期間により目標期間を延長した日数
+	ExtendedDays int `json:"extended_days,omitempty"`
+
+	// 目標期間内に公開されたが評価対象外としたエントリ
+	ExcludedEntries []*ExcludedEntry `json:"excluded_entries,omitempty"`
+
+	// サーキットブレーカーによりフェッチをスキップしたフェッチ元
+	DegradedSources []string `json:"degraded_sources,omitempty"`
 }
 
-func Analyze(latestEntry mo.Option[*Entry], now time.Time, goalType GoalType) *AnalysisReport {
+// Analyze は最新エントリによる目標と、targetPoints が正の場合はポイント目標を評価する。
+// rules を満たさないエントリは評価対象外とする。
+// pause の休止期間中は、休止モードに応じて評価をスキップするか目標期間を延長する。
+func Analyze(entries []*Entry, now time.Time, goalType GoalType, targetPoints int, rules *EligibilityRules, pause *PauseSetting) *AnalysisReport {
+	since, extendedDays := pause.GoalPeriodStart(now, goalType)
+	paused := pause.IsPaused(now)
+	skipped := paused && pause.Mode == PauseModeSkip
+
+	entries, excluded := FilterEligible(entries, rules)
+	excluded = excludedSince(excluded, since)
+
+	earnedPoints := EarnedPoints(entries, since)
+	isPointsGoalAchieved := !skipped && targetPoints > 0 && earnedPoints >= targetPoints
+	metrics := SummarizeContentMetrics(entries, since)
+
+	latestEntry := Latest(entries)
 	if latestEntry.IsAbsent() {
 		return &AnalysisReport{
-			IsGoalAchieved: false,
-			LatestEntry:    mo.None[*Entry](),
+			Goal:                 goalType,
+			GeneratedAt:          now,
+			IsGoalAchieved:       false,
+			LatestEntry:          mo.None[*Entry](),
+			EarnedPoints:         earnedPoints,
+			TargetPoints:         targetPoints,
+			IsPointsGoalAchieved: isPointsGoalAchieved,
+			Metrics:              metrics,
+			ExcludedEntries:      excluded,
+			Paused:               paused,
+			EvaluationSkipped:    skipped,
+			ExtendedDays:         extendedDays,
 		}
 	}
 
 	return &AnalysisReport{
-		IsGoalAchieved: IsGoalAchieved(latestEntry.MustGet().PublishedAt, now, goalType),
-		LatestEntry:    latestEntry,
+		Goal:                 goalType,
+		GeneratedAt:          now,
+		IsGoalAchieved:       !skipped && !latestEntry.MustGet().PublishedAt.Before(since),
+		LatestEntry:          latestEntry,
+		EarnedPoints:         earnedPoints,
+		TargetPoints:         targetPoints,
+		IsPointsGoalAchieved: isPointsGoalAchieved,
+		Metrics:              metrics,
+		ExcludedEntries:      excluded,
+		Paused:               paused,
+		EvaluationSkipped:    skipped,
+		ExtendedDays:         extendedDays,
 	}
 }
@@ -0,0 +1,50 @@
+package model
+
+import (
+	"time"
+
+	"github.com/samber/mo"
+)
+
+// CircuitBreakerState はフェッチ元ごとの連続失敗状況を表す。実行をまたいで永続化される。
+type CircuitBreakerState struct {
+	ConsecutiveFailures int                  `json:"consecutive_failures"`
+	OpenedAt            mo.Option[time.Time] `json:"opened_at"`
+}
+
+func NewCircuitBreakerState() *CircuitBreakerState {
+	return &CircuitBreakerState{
+		ConsecutiveFailures: 0,
+		OpenedAt:            mo.None[time.Time](),
+	}
+}
+
+// オープンしてから cooldown が経過するまではフェッチをスキップする。
+// cooldown 経過後はハーフオープンとして 1 回だけ試行を許可する。
+func (s *CircuitBreakerState) IsOpen(now time.Time, cooldown time.Duration) bool {
+	openedAt, ok := s.OpenedAt.Get()
+	if !ok {
+		return false
+	}
+	return now.Before(openedAt.Add(cooldown))
+}
+
+func (s *CircuitBreakerState) RecordSuccess() *CircuitBreakerState {
+	return NewCircuitBreakerState()
+}
+
+// 連続失敗回数が threshold に達した時点でオープンする。
+// ハーフオープン中の失敗は再度オープンし直す。
+func (s *CircuitBreakerState) RecordFailure(now time.Time, threshold int) *CircuitBreakerState {
+	failures := s.ConsecutiveFailures + 1
+	if failures < threshold {
+		return &CircuitBreakerState{
+			ConsecutiveFailures: failures,
+			OpenedAt:            mo.None[time.Time](),
+		}
+	}
+	return &CircuitBreakerState{
+		ConsecutiveFailures: failures,
+		OpenedAt:            mo.Some(now),
+	}
+}
@@ -0,0 +1,119 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerState_IsOpen(t *testing.T) {
+	type args struct {
+		state    *model.CircuitBreakerState
+		now      time.Time
+		cooldown time.Duration
+	}
+	tests := []struct {
+		name string
+		args args
+		want bool
+	}{
+		{
+			"closed when never opened",
+			args{
+				model.NewCircuitBreakerState(),
+				time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+				time.Hour,
+			},
+			false,
+		},
+		{
+			"open within cooldown",
+			args{
+				&model.CircuitBreakerState{
+					ConsecutiveFailures: 3,
+					OpenedAt:            mo.Some(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				},
+				time.Date(2025, 1, 10, 0, 59, 59, 0, time.UTC),
+				time.Hour,
+			},
+			true,
+		},
+		{
+			"half open after cooldown",
+			args{
+				&model.CircuitBreakerState{
+					ConsecutiveFailures: 3,
+					OpenedAt:            mo.Some(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				},
+				time.Date(2025, 1, 10, 1, 0, 0, 0, time.UTC),
+				time.Hour,
+			},
+			false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.args.state.IsOpen(tt.args.now, tt.args.cooldown))
+		})
+	}
+}
+
+func TestCircuitBreakerState_RecordFailure(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		state *model.CircuitBreakerState
+		want  *model.CircuitBreakerState
+	}{
+		{
+			"stay closed below threshold",
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 1,
+				OpenedAt:            mo.None[time.Time](),
+			},
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 2,
+				OpenedAt:            mo.None[time.Time](),
+			},
+		},
+		{
+			"open when reaching threshold",
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 2,
+				OpenedAt:            mo.None[time.Time](),
+			},
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 3,
+				OpenedAt:            mo.Some(now),
+			},
+		},
+		{
+			"reopen when half open attempt fails",
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 3,
+				OpenedAt:            mo.Some(now.Add(-24 * time.Hour)),
+			},
+			&model.CircuitBreakerState{
+				ConsecutiveFailures: 4,
+				OpenedAt:            mo.Some(now),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.state.RecordFailure(now, 3))
+		})
+	}
+}
+
+func TestCircuitBreakerState_RecordSuccess(t *testing.T) {
+	state := &model.CircuitBreakerState{
+		ConsecutiveFailures: 3,
+		OpenedAt:            mo.Some(time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+	}
+	assert.Equal(t, model.NewCircuitBreakerState(), state.RecordSuccess())
+}
@@ -0,0 +1,166 @@
+package model
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+)
+
+const (
+	maxDigestTopCategories = 5
+)
+
+// DigestPeriod はダイジェストの集計期間を表す。
+// NOTE: 目標とは独立して指定できるよう型を分ける。
+// 前期間と同じ長さで比較できるよう、暦の週や月ではなく実行日の 0 時から遡った日数で集計する
+type DigestPeriod int
+
+const (
+	DigestPeriodWeek DigestPeriod = iota + 1
+	DigestPeriodMonth
+)
+
+func (p DigestPeriod) String() string {
+	switch p {
+	case DigestPeriodWeek:
+		return "week"
+	case DigestPeriodMonth:
+		return "month"
+	}
+	return "unknown"
+}
+
+// Days は集計期間の日数を返す。week は直近 7 日、month は直近 30 日とする。
+func (p DigestPeriod) Days() int {
+	return lo.Ternary(p == DigestPeriodMonth, 30, 7)
+}
+
+// Start は now を終端とする集計期間の開始日時を返す。
+func (p DigestPeriod) Start(now time.Time) time.Time {
+	return date.AddDays(date.BeginningOfDay(now), -p.Days())
+}
+
+func ParseDigestPeriod(period string) (DigestPeriod, error) {
+	switch period {
+	case "week":
+		return DigestPeriodWeek, nil
+	case "month":
+		return DigestPeriodMonth, nil
+	default:
+		return 0, fmt.Errorf("unsupported digest period: %s", period)
+	}
+}
+
+// DigestReport は集計期間ごとのアウトプットの集計結果を表す。
+// 直前の同じ長さの期間と比較できるよう、前期間の集計結果も含む。
+type DigestReport struct {
+	// NOTE: 保存時はエンベロープに記録するため、本体には含めない
+	Period      DigestPeriod `json:"-"`
+	PeriodStart time.Time    `json:"period_start"`
+	PeriodEnd   time.Time    `json:"period_end"`
+	Current     DigestStats  `json:"current"`
+	Previous    DigestStats  `json:"previous"`
+	// 今期間に公開されたエントリのカテゴリ (タグ) の上位
+	TopCategories []CategoryCount `json:"top_categories"`
+}
+
+type DigestStats struct {
+	Entries int `json:"entries"`
+	// HTML を除去した本文の空白を除く文字数の合計
+	Characters int `json:"characters"`
+	Points     int `json:"points"`
+	// エントリ数の多い順に並べる
+	Platforms []PlatformCount `json:"platforms"`
+}
+
+type PlatformCount struct {
+	Platform string `json:"platform"`
+	Entries  int    `json:"entries"`
+}
+
+type CategoryCount struct {
+	Category string `json:"category"`
+	Entries  int    `json:"entries"`
+}
+
+// BuildDigestReport は now を終端とする集計期間と、その直前の同じ長さの期間のエントリを集計する。
+func BuildDigestReport(entries []*Entry, now time.Time, period DigestPeriod) *DigestReport {
+	start := period.Start(now)
+	previousStart := date.AddDays(start, -period.Days())
+
+	current := lo.Filter(entries, func(entry *Entry, _ int) bool {
+		return !entry.PublishedAt.Before(start) && !entry.PublishedAt.After(now)
+	})
+	previous := lo.Filter(entries, func(entry *Entry, _ int) bool {
+		return !entry.PublishedAt.Before(previousStart) && entry.PublishedAt.Before(start)
+	})
+
+	return &DigestReport{
+		Period:        period,
+		PeriodStart:   start,
+		PeriodEnd:     now,
+		Current:       summarizeDigestStats(current),
+		Previous:      summarizeDigestStats(previous),
+		TopCategories: topCategories(current, maxDigestTopCategories),
+	}
+}
+
+func summarizeDigestStats(entries []*Entry) DigestStats {
+	platforms := lo.MapToSlice(
+		lo.CountValuesBy(entries, func(entry *Entry) string {
+			return entry.Platform.Name
+		}),
+		func(platform string, count int) PlatformCount {
+			return PlatformCount{Platform: platform, Entries: count}
+		},
+	)
+	slices.SortFunc(platforms, func(a, b PlatformCount) int {
+		return cmp.Or(cmp.Compare(b.Entries, a.Entries), strings.Compare(a.Platform, b.Platform))
+	})
+
+	return DigestStats{
+		Entries: len(entries),
+		Characters: lo.SumBy(entries, func(entry *Entry) int {
+			return countCharacters(PlainText(entry.Body))
+		}),
+		Points:    lo.SumBy(entries, Points),
+		Platforms: platforms,
+	}
+}
+
+// topCategories はカテゴリを含むエントリ数の多い順に上位 n 件を返す。
+// NOTE: プラットフォームによって表記揺れがあるため、大文字小文字を区別せずに集計し、最初に出現した表記を使う
+func topCategories(entries []*Entry, n int) []CategoryCount {
+	categoryKey := func(tag string) string {
+		return strings.ToLower(strings.TrimSpace(tag))
+	}
+
+	counts := map[string]*CategoryCount{}
+	var order []string
+	for _, entry := range entries {
+		for _, tag := range lo.UniqBy(entry.Tags, categoryKey) {
+			key := categoryKey(tag)
+			if key == "" {
+				continue
+			}
+			if _, ok := counts[key]; !ok {
+				counts[key] = &CategoryCount{Category: strings.TrimSpace(tag)}
+				order = append(order, key)
+			}
+			counts[key].Entries++
+		}
+	}
+
+	categories := lo.Map(order, func(key string, _ int) CategoryCount {
+		return *counts[key]
+	})
+	slices.SortStableFunc(categories, func(a, b CategoryCount) int {
+		return cmp.Compare(b.Entries, a.Entries)
+	})
+	return lo.Subset(categories, 0, uint(n))
+}
@@ -0,0 +1,102 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildDigestReport(t *testing.T) {
+	now := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	entries := []*model.Entry{
+		{
+			Title:       "Rustを学ぶ",
+			Body:        "<p>" + strings.Repeat("あ", 100) + "</p>",
+			Tags:        []string{"Rust", "rust", "学習"},
+			PublishedAt: time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		},
+		{
+			Title:       "Rustの所有権",
+			Body:        strings.Repeat("い", 50),
+			Tags:        []string{"RUST"},
+			PublishedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformHatena(),
+		},
+		{
+			Title:       "Go で作るアウトプット応援ツール",
+			PublishedAt: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+			Tags:        []string{"Go"},
+			Platform:    model.EntryPlatformSpeakerDeck(3),
+		},
+		{
+			Title:       "Goを学ぶ",
+			Body:        strings.Repeat("う", 10),
+			PublishedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		},
+		{
+			Title:       "outdated",
+			PublishedAt: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+			Platform:    model.EntryPlatformZenn(),
+		},
+	}
+
+	assert.Equal(t, &model.DigestReport{
+		Period:      model.DigestPeriodWeek,
+		PeriodStart: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   now,
+		Current: model.DigestStats{
+			Entries:    3,
+			Characters: 150,
+			Points:     5,
+			Platforms: []model.PlatformCount{
+				{Platform: "Speaker Deck", Entries: 1},
+				{Platform: "Zenn", Entries: 1},
+				{Platform: "はてなブログ", Entries: 1},
+			},
+		},
+		Previous: model.DigestStats{
+			Entries:    1,
+			Characters: 10,
+			Points:     1,
+			Platforms: []model.PlatformCount{
+				{Platform: "Zenn", Entries: 1},
+			},
+		},
+		TopCategories: []model.CategoryCount{
+			{Category: "Rust", Entries: 2},
+			{Category: "学習", Entries: 1},
+			{Category: "Go", Entries: 1},
+		},
+	}, model.BuildDigestReport(entries, now, model.DigestPeriodWeek))
+}
+
+func TestParseDigestPeriod(t *testing.T) {
+	tests := []struct {
+		period    string
+		want      model.DigestPeriod
+		wantStart time.Time
+		wantErr   bool
+	}{
+		{period: "week", want: model.DigestPeriodWeek, wantStart: time.Date(2025, 1, 8, 0, 0, 0, 0, time.UTC)},
+		// NOTE: 暦の月ではなく直近 30 日を集計する
+		{period: "month", want: model.DigestPeriodMonth, wantStart: time.Date(2024, 12, 16, 0, 0, 0, 0, time.UTC)},
+		{period: "recent_week", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.period, func(t *testing.T) {
+			got, err := model.ParseDigestPeriod(tt.period)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantStart, got.Start(time.Date(2025, 1, 15, 12, 0, 0, 0, time.UTC)))
+		})
+	}
+}
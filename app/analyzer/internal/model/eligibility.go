@@ -0,0 +1,79 @@
+package model
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+)
+
+type ExclusionReason string
+
+const (
+	ExclusionReasonTooShort      ExclusionReason = "too_short"
+	ExclusionReasonExcludedTag   ExclusionReason = "excluded_tag"
+	ExclusionReasonExcludedTitle ExclusionReason = "excluded_title"
+)
+
+// EligibilityRules は目標の評価対象とするエントリの条件を表す。
+// ゼロ値の場合は全てのエントリを評価対象とする。
+type EligibilityRules struct {
+	// HTML を除去した本文の最小文字数 (空白を除く)
+	// NOTE: 登壇は本文にスライドの概要しか含まないため、記事にのみ適用する
+	MinCharacters int
+	// 大文字小文字を区別せずに比較する
+	ExcludedTags         []string
+	ExcludedTitlePattern *regexp.Regexp
+}
+
+type ExcludedEntry struct {
+	Entry  *Entry          `json:"entry"`
+	Reason ExclusionReason `json:"reason"`
+}
+
+// Check はエントリが評価対象外の場合にその理由を返す。
+func (r *EligibilityRules) Check(entry *Entry) mo.Option[ExclusionReason] {
+	if r == nil {
+		return mo.None[ExclusionReason]()
+	}
+	if r.ExcludedTitlePattern != nil && r.ExcludedTitlePattern.MatchString(entry.Title) {
+		return mo.Some(ExclusionReasonExcludedTitle)
+	}
+	if lo.SomeBy(entry.Tags, func(tag string) bool {
+		return lo.ContainsBy(r.ExcludedTags, func(excluded string) bool {
+			return strings.EqualFold(tag, excluded)
+		})
+	}) {
+		return mo.Some(ExclusionReasonExcludedTag)
+	}
+	if r.MinCharacters > 0 && entry.Platform.Type.Kind() == EntryKindArticle && countCharacters(PlainText(entry.Body)) < r.MinCharacters {
+		return mo.Some(ExclusionReasonTooShort)
+	}
+	return mo.None[ExclusionReason]()
+}
+
+// FilterEligible はエントリを評価対象と評価対象外に振り分ける。
+func FilterEligible(entries []*Entry, rules *EligibilityRules) (eligible []*Entry, excluded []*ExcludedEntry) {
+	for _, entry := range entries {
+		if reason, ok := rules.Check(entry).Get(); ok {
+			excluded = append(excluded, &ExcludedEntry{Entry: entry, Reason: reason})
+			continue
+		}
+		eligible = append(eligible, entry)
+	}
+	return eligible, excluded
+}
+
+// excludedSince は since 以降に公開された評価対象外のエントリを返す。
+// NOTE: 目標期間外のエントリは目標の評価に影響しないため、レポートの肥大化を避けて除外する
+func excludedSince(excluded []*ExcludedEntry, since time.Time) []*ExcludedEntry {
+	var inGoalPeriod []*ExcludedEntry
+	for _, e := range excluded {
+		if !e.Entry.PublishedAt.Before(since) {
+			inGoalPeriod = append(inGoalPeriod, e)
+		}
+	}
+	return inGoalPeriod
+}
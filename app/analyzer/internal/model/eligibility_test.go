@@ -0,0 +1,90 @@
+package model_test
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEligibilityRules_Check(t *testing.T) {
+	rules := &model.EligibilityRules{
+		MinCharacters:        10,
+		ExcludedTags:         []string{"Memo"},
+		ExcludedTitlePattern: regexp.MustCompile(`^\[WIP\]`),
+	}
+
+	tests := []struct {
+		name  string
+		rules *model.EligibilityRules
+		entry *model.Entry
+		want  mo.Option[model.ExclusionReason]
+	}{
+		{
+			"eligible entry",
+			rules,
+			&model.Entry{
+				Title:    "Rustを学ぶ",
+				Body:     "<p>Rustには所有権という概念があります</p>",
+				Platform: model.EntryPlatformZenn(),
+			},
+			mo.None[model.ExclusionReason](),
+		},
+		{
+			"too short body excluding html tags",
+			rules,
+			&model.Entry{
+				Title:    "Rustを学ぶ",
+				Body:     "<p><strong>所有権</strong> とは</p>",
+				Platform: model.EntryPlatformZenn(),
+			},
+			mo.Some(model.ExclusionReasonTooShort),
+		},
+		{
+			"min characters is not applied to talks",
+			rules,
+			&model.Entry{
+				Title:    "Rustを学ぶ",
+				Body:     "登壇資料",
+				Platform: model.EntryPlatformSpeakerDeck(3),
+			},
+			mo.None[model.ExclusionReason](),
+		},
+		{
+			"excluded tag ignoring case",
+			rules,
+			&model.Entry{
+				Title:    "Rustを学ぶ",
+				Body:     "Rustには所有権という概念があります",
+				Tags:     []string{"rust", "memo"},
+				Platform: model.EntryPlatformZenn(),
+			},
+			mo.Some(model.ExclusionReasonExcludedTag),
+		},
+		{
+			"excluded title",
+			rules,
+			&model.Entry{
+				Title:    "[WIP] Rustを学ぶ",
+				Body:     "Rustには所有権という概念があります",
+				Platform: model.EntryPlatformZenn(),
+			},
+			mo.Some(model.ExclusionReasonExcludedTitle),
+		},
+		{
+			"nil rules",
+			nil,
+			&model.Entry{
+				Title: "[WIP] メモ",
+			},
+			mo.None[model.ExclusionReason](),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rules.Check(tt.entry))
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 type Entry struct {
 	Title       string
 	Body        string
+	Tags        []string
 	PublishedAt time.Time
 	Platform    EntryPlatform
 }
@@ -24,17 +25,30 @@ const (
 	GoalTypeRecentMonth GoalType = iota + 1
 )
 
+func (t GoalType) String() string {
+	switch t {
+	case GoalTypeRecentWeek:
+		return "recent_week"
+	case GoalTypeRecentMonth:
+		return "recent_month"
+	}
+	return "unknown"
+}
+
 // 現在日の00:00(JST)からn日遡った日時以降に公開されていれば目標達成とみなす
 func IsGoalAchieved(publishedAt, now time.Time, goalType GoalType) bool {
+	return !publishedAt.Before(GoalPeriodStart(now, goalType))
+}
+
+// GoalPeriodStart は目標期間の開始日時を返す。
+func GoalPeriodStart(now time.Time, goalType GoalType) time.Time {
 	beginningOfToday := date.BeginningOfDay(now)
-	beginningOfBeforeXdays := date.AddDays(
+	return date.AddDays(
 		beginningOfToday,
 		lo.If(goalType == GoalTypeRecentWeek, -7).
 			ElseIf(goalType == GoalTypeRecentMonth, -30).
 			Else(-7),
 	)
-
-	return !publishedAt.Before(beginningOfBeforeXdays)
 }
 
 type EntryPlatformType int
@@ -43,24 +57,98 @@ const (
 	EntryPlatformTypeZero EntryPlatformType = iota
 	EntryPlatformTypeZenn
 	EntryPlatformTypeHatena
+	// 設定で追加された RSS/Atom/JSON Feed
+	EntryPlatformTypeFeed
+	// ローカルの Markdown ファイル
+	EntryPlatformTypeMarkdown
+	// ローカルの Git リポジトリで管理されている記事
+	EntryPlatformTypeGitRepository
+	// Speaker Deck や Docswell に公開された登壇資料
+	EntryPlatformTypeSlide
 )
 
+type EntryKind int
+
+const (
+	EntryKindArticle EntryKind = iota + 1
+	EntryKindTalk
+)
+
+func (t EntryPlatformType) Kind() EntryKind {
+	if t == EntryPlatformTypeSlide {
+		return EntryKindTalk
+	}
+	return EntryKindArticle
+}
+
 type EntryPlatform struct {
 	Type     EntryPlatformType
+	Name     string
 	Priority int
+	// アウトプット 1 件あたりの重み
+	Weight int
 }
 
 func EntryPlatformHatena() EntryPlatform {
 	return EntryPlatform{
 		Type:     EntryPlatformTypeHatena,
+		Name:     "はてなブログ",
 		Priority: 1,
+		Weight:   1,
 	}
 }
 
 func EntryPlatformZenn() EntryPlatform {
 	return EntryPlatform{
 		Type:     EntryPlatformTypeZenn,
+		Name:     "Zenn",
 		Priority: 2,
+		Weight:   1,
+	}
+}
+
+func EntryPlatformMarkdown() EntryPlatform {
+	return EntryPlatform{
+		Type:     EntryPlatformTypeMarkdown,
+		Name:     "Markdown",
+		Priority: 3,
+		Weight:   1,
+	}
+}
+
+func EntryPlatformGitRepository() EntryPlatform {
+	return EntryPlatform{
+		Type:     EntryPlatformTypeGitRepository,
+		Name:     "Git",
+		Priority: 4,
+		Weight:   1,
+	}
+}
+
+func EntryPlatformSpeakerDeck(weight int) EntryPlatform {
+	return EntryPlatform{
+		Type:     EntryPlatformTypeSlide,
+		Name:     "Speaker Deck",
+		Priority: 5,
+		Weight:   weight,
+	}
+}
+
+func EntryPlatformDocswell(weight int) EntryPlatform {
+	return EntryPlatform{
+		Type:     EntryPlatformTypeSlide,
+		Name:     "Docswell",
+		Priority: 6,
+		Weight:   weight,
+	}
+}
+
+func EntryPlatformFeed(name string, priority int) EntryPlatform {
+	return EntryPlatform{
+		Type:     EntryPlatformTypeFeed,
+		Name:     name,
+		Priority: priority,
+		Weight:   1,
 	}
 }
 
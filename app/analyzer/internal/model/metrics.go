@@ -0,0 +1,199 @@
+package model
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/samber/lo"
+	"golang.org/x/net/html"
+)
+
+const (
+	// NOTE: 日本語は 1 分あたり 500 文字、英語などの分かち書きする言語は 1 分あたり 200 語を読めるものとする
+	japaneseCharactersPerMinute = 500
+	wordsPerMinute              = 200
+)
+
+var (
+	markdownHeadingPattern = regexp.MustCompile(`^#{1,6}\s`)
+	markdownImagePattern   = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+)
+
+// ContentMetrics はエントリ本文の量と構成を表す。
+type ContentMetrics struct {
+	// 空白を除いた文字数
+	Characters int `json:"characters"`
+	// 読了までの推定時間 (分)
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	CodeBlocks         int `json:"code_blocks"`
+	Images             int `json:"images"`
+	Headings           int `json:"headings"`
+}
+
+// ContentMetricsSummary は目標期間内に公開されたエントリのメトリクスを集計したもの。
+type ContentMetricsSummary struct {
+	Entries int `json:"entries"`
+	// エントリあたりの平均文字数
+	AverageCharacters int `json:"average_characters"`
+	ContentMetrics
+}
+
+// MeasureContent は本文からメトリクスを計測する。
+// NOTE: 本文は HTML と Markdown のどちらの場合もある。
+// HTML の場合は要素から、Markdown の場合は記法からコードブロック、画像、見出しを数える。
+func MeasureContent(body string) ContentMetrics {
+	text := PlainText(body)
+	htmlMetrics := measureHTML(body)
+	markdownMetrics := measureMarkdown(htmlMetrics.textOutsidePre)
+
+	japaneseCharacters, words := countJapaneseCharactersAndWords(text)
+	return ContentMetrics{
+		Characters:         countCharacters(text),
+		ReadingTimeMinutes: readingTimeMinutes(japaneseCharacters, words),
+		CodeBlocks:         htmlMetrics.codeBlocks + markdownMetrics.codeBlocks,
+		Images:             htmlMetrics.images + markdownMetrics.images,
+		Headings:           htmlMetrics.headings + markdownMetrics.headings,
+	}
+}
+
+// SummarizeContentMetrics は since 以降に公開されたエントリのメトリクスを集計する。
+func SummarizeContentMetrics(entries []*Entry, since time.Time) ContentMetricsSummary {
+	metrics := lo.FilterMap(entries, func(entry *Entry, _ int) (ContentMetrics, bool) {
+		if entry.PublishedAt.Before(since) {
+			return ContentMetrics{}, false
+		}
+		return MeasureContent(entry.Body), true
+	})
+
+	var total ContentMetrics
+	for _, m := range metrics {
+		total.Characters += m.Characters
+		total.ReadingTimeMinutes += m.ReadingTimeMinutes
+		total.CodeBlocks += m.CodeBlocks
+		total.Images += m.Images
+		total.Headings += m.Headings
+	}
+
+	averageCharacters := 0
+	if len(metrics) > 0 {
+		averageCharacters = total.Characters / len(metrics)
+	}
+	return ContentMetricsSummary{
+		Entries:           len(metrics),
+		AverageCharacters: averageCharacters,
+		ContentMetrics:    total,
+	}
+}
+
+type elementMetrics struct {
+	codeBlocks int
+	images     int
+	headings   int
+	// pre 要素の外側のテキスト。Markdown 記法の検出に使う
+	textOutsidePre string
+}
+
+func measureHTML(body string) elementMetrics {
+	var (
+		metrics elementMetrics
+		text    strings.Builder
+		pre     int
+		skip    int
+	)
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			metrics.textOutsidePre = text.String()
+			return metrics
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "pre":
+				metrics.codeBlocks++
+				pre++
+			case tag == "img":
+				metrics.images++
+			case isHeadingTag(tag):
+				metrics.headings++
+			case isSkippedTag(tag):
+				skip++
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			switch tag := string(name); {
+			case tag == "pre" && pre > 0:
+				pre--
+			case isSkippedTag(tag) && skip > 0:
+				skip--
+			}
+		case html.TextToken:
+			if pre == 0 && skip == 0 {
+				text.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+func isHeadingTag(name string) bool {
+	return len(name) == 2 && name[0] == 'h' && name[1] >= '1' && name[1] <= '6'
+}
+
+// NOTE: フェンス内の行は見出しや画像として数えない
+func measureMarkdown(text string) elementMetrics {
+	var (
+		metrics elementMetrics
+		fence   string
+	)
+	for line := range strings.Lines(text) {
+		trimmed := strings.TrimSpace(line)
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fence = trimmed[:3]
+			metrics.codeBlocks++
+			continue
+		}
+		if markdownHeadingPattern.MatchString(trimmed) {
+			metrics.headings++
+		}
+		metrics.images += len(markdownImagePattern.FindAllString(line, -1))
+	}
+	return metrics
+}
+
+// countJapaneseCharactersAndWords は日本語の文字数と、それ以外の文字からなる語数を返す。
+func countJapaneseCharactersAndWords(text string) (japaneseCharacters, words int) {
+	inWord := false
+	for _, r := range text {
+		switch {
+		case isJapanese(r):
+			japaneseCharacters++
+			inWord = false
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			if !inWord {
+				words++
+			}
+			inWord = true
+		default:
+			inWord = false
+		}
+	}
+	return japaneseCharacters, words
+}
+
+func isJapanese(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana) || r == 'ー'
+}
+
+func readingTimeMinutes(japaneseCharacters, words int) int {
+	minutes := float64(japaneseCharacters)/japaneseCharactersPerMinute + float64(words)/wordsPerMinute
+	return int(math.Ceil(minutes))
+}
@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureContent(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want model.ContentMetrics
+	}{
+		{
+			"empty body",
+			"",
+			model.ContentMetrics{},
+		},
+		{
+			"html body",
+			`<h2>所有権</h2><p>Rustには所有権があります</p><img src="a.png"><pre><code># not heading</code></pre><h3>借用</h3><script>alert("x")</script>`,
+			model.ContentMetrics{
+				Characters:         len([]rune("所有権Rustには所有権があります#notheading借用")),
+				ReadingTimeMinutes: 1,
+				CodeBlocks:         1,
+				Images:             1,
+				Headings:           2,
+			},
+		},
+		{
+			"markdown body",
+			"## 所有権\n\n![図](a.png) ![図](b.png)\n\n```go\n# not heading\n```\n\n### 借用\n",
+			model.ContentMetrics{
+				Characters:         len([]rune("##所有権![図](a.png)![図](b.png)```go#notheading```###借用")),
+				ReadingTimeMinutes: 1,
+				CodeBlocks:         1,
+				Images:             2,
+				Headings:           2,
+			},
+		},
+		{
+			"reading time counts japanese characters and english words",
+			strings.Repeat("あ", 500) + strings.Repeat(" word", 201),
+			model.ContentMetrics{
+				Characters:         500 + 4*201,
+				ReadingTimeMinutes: 3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.MeasureContent(tt.body))
+		})
+	}
+}
+
+func TestSummarizeContentMetrics(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	entries := []*model.Entry{
+		{
+			Body:        "<h2>所有権</h2><p>" + strings.Repeat("あ", 997) + "</p>",
+			PublishedAt: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Body:        "<p>" + strings.Repeat("い", 500) + `</p><img src="a.png">`,
+			PublishedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			Body:        "<pre>outdated</pre>",
+			PublishedAt: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	assert.Equal(t, model.ContentMetricsSummary{
+		Entries:           2,
+		AverageCharacters: 750,
+		ContentMetrics: model.ContentMetrics{
+			Characters:         1500,
+			ReadingTimeMinutes: 3,
+			Images:             1,
+			Headings:           1,
+		},
+	}, model.SummarizeContentMetrics(entries, model.GoalPeriodStart(now, model.GoalTypeRecentWeek)))
+}
@@ -0,0 +1,105 @@
+package model
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+)
+
+// PausePeriod は休暇などで目標の評価を休止する期間を表す。
+// Start と End は JST の日付の 00:00 とし、End の日も休止期間に含む。
+type PausePeriod struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// NewPausePeriod は YYYY-MM-DD 形式の開始日と終了日から休止期間を生成する。
+func NewPausePeriod(start, end, reason string) (PausePeriod, error) {
+	startDate, err := time.ParseInLocation(time.DateOnly, start, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return PausePeriod{}, fmt.Errorf("invalid start date: %w", err)
+	}
+	endDate, err := time.ParseInLocation(time.DateOnly, end, lo.ToPtr(date.LocationJST()))
+	if err != nil {
+		return PausePeriod{}, fmt.Errorf("invalid end date: %w", err)
+	}
+	if endDate.Before(startDate) {
+		return PausePeriod{}, errors.New("end date must not be before start date")
+	}
+	return PausePeriod{
+		Start:  startDate,
+		End:    endDate,
+		Reason: reason,
+	}, nil
+}
+
+func (p PausePeriod) Contains(t time.Time) bool {
+	return !t.Before(p.Start) && t.Before(date.AddDays(p.End, 1))
+}
+
+type PauseMode string
+
+const (
+	// 休止期間中は目標を評価しない
+	PauseModeSkip PauseMode = "skip"
+	// 目標期間に含まれる休止日数だけ目標期間を過去に延長して評価する
+	PauseModeExtend PauseMode = "extend"
+)
+
+func ParsePauseMode(s string) (PauseMode, error) {
+	switch PauseMode(s) {
+	case PauseModeSkip, PauseModeExtend:
+		return PauseMode(s), nil
+	}
+	return "", fmt.Errorf("unsupported pause mode: %s", s)
+}
+
+type PauseSetting struct {
+	Periods []PausePeriod
+	Mode    PauseMode
+}
+
+// IsPaused は now が休止期間中かどうかを返す。
+func (s *PauseSetting) IsPaused(now time.Time) bool {
+	if s == nil {
+		return false
+	}
+	return lo.SomeBy(s.Periods, func(p PausePeriod) bool {
+		return p.Contains(now)
+	})
+}
+
+// GoalPeriodStart は休止期間を考慮した目標期間の開始日時と、延長した日数を返す。
+// NOTE: 延長した先にも休止期間が含まれる場合があるため、延長日数が変わらなくなるまで繰り返す
+func (s *PauseSetting) GoalPeriodStart(now time.Time, goalType GoalType) (time.Time, int) {
+	start := GoalPeriodStart(now, goalType)
+	if s == nil || s.Mode != PauseModeExtend {
+		return start, 0
+	}
+
+	extendedDays := 0
+	for {
+		days := s.pausedDays(date.AddDays(start, -extendedDays), now)
+		if days == extendedDays {
+			return date.AddDays(start, -extendedDays), extendedDays
+		}
+		extendedDays = days
+	}
+}
+
+// pausedDays は from から now が属する日までのうち、休止期間に含まれる日数を返す。
+func (s *PauseSetting) pausedDays(from, now time.Time) int {
+	days := 0
+	for day := from; day.Before(now); day = date.AddDays(day, 1) {
+		if lo.SomeBy(s.Periods, func(p PausePeriod) bool {
+			return p.Contains(day)
+		}) {
+			days++
+		}
+	}
+	return days
+}
@@ -0,0 +1,169 @@
+package model_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mustPausePeriod(t *testing.T, start, end string) model.PausePeriod {
+	t.Helper()
+	period, err := model.NewPausePeriod(start, end, "")
+	require.NoError(t, err)
+	return period
+}
+
+func TestNewPausePeriod(t *testing.T) {
+	_, err := model.NewPausePeriod("2025-01-10", "2025-01-09", "")
+	assert.Error(t, err)
+
+	_, err = model.NewPausePeriod("2025/01/10", "2025-01-12", "")
+	assert.Error(t, err)
+
+	period, err := model.NewPausePeriod("2025-01-10", "2025-01-10", "休暇")
+	require.NoError(t, err)
+	jst := lo.ToPtr(date.LocationJST())
+	assert.True(t, period.Contains(time.Date(2025, 1, 10, 0, 0, 0, 0, jst)))
+	assert.True(t, period.Contains(time.Date(2025, 1, 10, 23, 59, 59, 0, jst)))
+	assert.False(t, period.Contains(time.Date(2025, 1, 11, 0, 0, 0, 0, jst)))
+	assert.False(t, period.Contains(time.Date(2025, 1, 9, 23, 59, 59, 0, jst)))
+}
+
+func TestPauseSetting_GoalPeriodStart(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	now := time.Date(2025, 1, 20, 9, 0, 0, 0, jst)
+
+	tests := []struct {
+		name             string
+		setting          *model.PauseSetting
+		wantStart        time.Time
+		wantExtendedDays int
+	}{
+		{
+			"nil setting",
+			nil,
+			time.Date(2025, 1, 13, 0, 0, 0, 0, jst),
+			0,
+		},
+		{
+			"skip mode does not extend",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{mustPausePeriod(t, "2025-01-15", "2025-01-17")},
+				Mode:    model.PauseModeSkip,
+			},
+			time.Date(2025, 1, 13, 0, 0, 0, 0, jst),
+			0,
+		},
+		{
+			"extend by paused days in goal period",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{mustPausePeriod(t, "2025-01-15", "2025-01-17")},
+				Mode:    model.PauseModeExtend,
+			},
+			time.Date(2025, 1, 10, 0, 0, 0, 0, jst),
+			3,
+		},
+		{
+			"extend again when extended period contains pause",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{
+					mustPausePeriod(t, "2025-01-15", "2025-01-17"),
+					mustPausePeriod(t, "2025-01-09", "2025-01-11"),
+				},
+				Mode: model.PauseModeExtend,
+			},
+			time.Date(2025, 1, 7, 0, 0, 0, 0, jst),
+			6,
+		},
+		{
+			"ignore pause outside goal period",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{mustPausePeriod(t, "2025-01-01", "2025-01-05")},
+				Mode:    model.PauseModeExtend,
+			},
+			time.Date(2025, 1, 13, 0, 0, 0, 0, jst),
+			0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, extendedDays := tt.setting.GoalPeriodStart(now, model.GoalTypeRecentWeek)
+			assert.True(t, tt.wantStart.Equal(start), "want %s, got %s", tt.wantStart, start)
+			assert.Equal(t, tt.wantExtendedDays, extendedDays)
+		})
+	}
+}
+
+func TestAnalyze_Pause(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	now := time.Date(2025, 1, 20, 9, 0, 0, 0, jst)
+	entry := &model.Entry{
+		Title:       "Rustを学ぶ",
+		PublishedAt: time.Date(2025, 1, 11, 12, 0, 0, 0, jst),
+		Platform:    model.EntryPlatformZenn(),
+	}
+
+	tests := []struct {
+		name    string
+		setting *model.PauseSetting
+		want    *model.AnalysisReport
+	}{
+		{
+			"not achieved without pause",
+			nil,
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved: false,
+				LatestEntry:    mo.Some(entry),
+			},
+		},
+		{
+			"skip evaluation during pause",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{mustPausePeriod(t, "2025-01-18", "2025-01-25")},
+				Mode:    model.PauseModeSkip,
+			},
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved:    false,
+				LatestEntry:       mo.Some(entry),
+				Paused:            true,
+				EvaluationSkipped: true,
+			},
+		},
+		{
+			"achieved by extending goal period",
+			&model.PauseSetting{
+				Periods: []model.PausePeriod{mustPausePeriod(t, "2025-01-18", "2025-01-25")},
+				Mode:    model.PauseModeExtend,
+			},
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved: true,
+				LatestEntry:    mo.Some(entry),
+				EarnedPoints:   1,
+				Metrics:        model.ContentMetricsSummary{Entries: 1},
+				Paused:         true,
+				ExtendedDays:   3,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.Analyze([]*model.Entry{entry}, now, model.GoalTypeRecentWeek, 0, nil, tt.setting)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
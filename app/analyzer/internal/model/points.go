@@ -0,0 +1,44 @@
+package model
+
+import (
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/samber/lo"
+)
+
+const (
+	// 本文の文字数がこの値に達するごとにボーナスポイントを加算する
+	charactersPerBonusPoint = 2000
+	maxBonusPoints          = 2
+)
+
+// Points はエントリ 1 件あたりのポイントを返す。
+// プラットフォームの重みに、HTML を除去した本文の文字数に応じたボーナスを加算する。
+// NOTE: 重みが未設定のプラットフォームは重み 1 とみなす
+func Points(entry *Entry) int {
+	weight := max(entry.Platform.Weight, 1)
+	return weight + min(countCharacters(PlainText(entry.Body))/charactersPerBonusPoint, maxBonusPoints)
+}
+
+// EarnedPoints は since 以降に公開されたエントリのポイントの合計を返す。
+func EarnedPoints(entries []*Entry, since time.Time) int {
+	return lo.SumBy(entries, func(entry *Entry) int {
+		if entry.PublishedAt.Before(since) {
+			return 0
+		}
+		return Points(entry)
+	})
+}
+
+// countCharacters は空白を除いた文字数を返す。
+func countCharacters(text string) int {
+	return utf8.RuneCountInString(strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text))
+}
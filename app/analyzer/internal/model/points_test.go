@@ -0,0 +1,141 @@
+package model_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPoints(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry *model.Entry
+		want  int
+	}{
+		{
+			"short article earns platform weight",
+			&model.Entry{
+				Body:     "<p>Rustには所有権という概念があります</p>",
+				Platform: model.EntryPlatformZenn(),
+			},
+			1,
+		},
+		{
+			"treat unset weight as 1",
+			&model.Entry{
+				Body: "Rustには所有権という概念があります",
+			},
+			1,
+		},
+		{
+			"long article earns bonus excluding html tags",
+			&model.Entry{
+				Body:     "<h2>所有権</h2><p>" + strings.Repeat("あ", 2000) + "</p>",
+				Platform: model.EntryPlatformHatena(),
+			},
+			2,
+		},
+		{
+			"bonus is capped",
+			&model.Entry{
+				Body:     strings.Repeat("あ", 100000),
+				Platform: model.EntryPlatformHatena(),
+			},
+			3,
+		},
+		{
+			"talk earns slide weight",
+			&model.Entry{
+				Body:     "Go で作るアウトプット応援ツール",
+				Platform: model.EntryPlatformSpeakerDeck(3),
+			},
+			3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, model.Points(tt.entry))
+		})
+	}
+}
+
+func TestAnalyze_Points(t *testing.T) {
+	now := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	talk := &model.Entry{
+		Title:       "Go で作るアウトプット応援ツール",
+		PublishedAt: time.Date(2025, 1, 9, 0, 0, 0, 0, time.UTC),
+		Platform:    model.EntryPlatformSpeakerDeck(3),
+	}
+	article := &model.Entry{
+		Title:       "Rustを学ぶ",
+		PublishedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+		Platform:    model.EntryPlatformHatena(),
+	}
+	outdated := &model.Entry{
+		Title:       "Goを学ぶ",
+		PublishedAt: time.Date(2024, 12, 1, 0, 0, 0, 0, time.UTC),
+		Platform:    model.EntryPlatformZenn(),
+	}
+
+	tests := []struct {
+		name         string
+		targetPoints int
+		want         *model.AnalysisReport
+	}{
+		{
+			"achieve points goal",
+			4,
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved:       true,
+				LatestEntry:          mo.Some(talk),
+				EarnedPoints:         4,
+				TargetPoints:         4,
+				IsPointsGoalAchieved: true,
+				Metrics:              model.ContentMetricsSummary{Entries: 2},
+			},
+		},
+		{
+			"not achieve points goal",
+			5,
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved:       true,
+				LatestEntry:          mo.Some(talk),
+				EarnedPoints:         4,
+				TargetPoints:         5,
+				IsPointsGoalAchieved: false,
+				Metrics:              model.ContentMetricsSummary{Entries: 2},
+			},
+		},
+		{
+			"skip points goal when target is not set",
+			0,
+			&model.AnalysisReport{
+				Goal:        model.GoalTypeRecentWeek,
+				GeneratedAt: now,
+
+				IsGoalAchieved:       true,
+				LatestEntry:          mo.Some(talk),
+				EarnedPoints:         4,
+				TargetPoints:         0,
+				IsPointsGoalAchieved: false,
+				Metrics:              model.ContentMetricsSummary{Entries: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := model.Analyze([]*model.Entry{outdated, article, talk}, now, model.GoalTypeRecentWeek, tt.targetPoints, nil, nil)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
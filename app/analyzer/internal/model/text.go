@@ -0,0 +1,37 @@
+package model
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// PlainText は本文から HTML タグを取り除いたテキストを返す。
+// NOTE: script と style の中身は本文とみなさない
+func PlainText(body string) string {
+	var b strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	skip := 0
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			return strings.TrimSpace(b.String())
+		case html.StartTagToken:
+			if name, _ := tokenizer.TagName(); isSkippedTag(string(name)) {
+				skip++
+			}
+		case html.EndTagToken:
+			if name, _ := tokenizer.TagName(); isSkippedTag(string(name)) && skip > 0 {
+				skip--
+			}
+		case html.TextToken:
+			if skip == 0 {
+				b.Write(tokenizer.Text())
+			}
+		}
+	}
+}
+
+func isSkippedTag(name string) bool {
+	return name == "script" || name == "style"
+}
@@ -0,0 +1,12 @@
+package circuitbreaker
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+// NOTE: 状態が未保存の場合は初期状態を返す
+type LoadState = func(ctx context.Context, source string) mo.Result[*model.CircuitBreakerState]
+type SaveState = func(ctx context.Context, source string, state *model.CircuitBreakerState) error
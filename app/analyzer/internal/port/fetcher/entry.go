@@ -2,9 +2,28 @@ package fetcher
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/samber/mo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 )
 
-type FetchLatestEntry = func(context.Context) mo.Result[mo.Option[*model.Entry]]
+// FetchEntries は取得可能なエントリを返す。最新のエントリの選択は呼び出し側で行う。
+type FetchEntries = func(context.Context) mo.Result[[]*model.Entry]
+
+// EntrySource はフェッチ元の名前とフェッチャーを表す。
+type EntrySource struct {
+	Name  string
+	Fetch FetchEntries
+	// Complete は過去の全てのエントリを返すかどうかを表す。直近のエントリのみを返す RSS などのフィードは false とする
+	Complete bool
+}
+
+// DegradedError は失敗が続いているためフェッチをスキップしたことを表す。
+type DegradedError struct {
+	Source string
+}
+
+func (e *DegradedError) Error() string {
+	return fmt.Sprintf("source %s is degraded, skipped fetching", e.Source)
+}
@@ -2,9 +2,57 @@ package locker
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	"github.com/samber/mo"
 )
 
-type Acquire = func(ctx context.Context, lockID string) mo.Result[bool]
-type Release = func(ctx context.Context, lockID string) error
+// Lease は取得したロックを表す。
+type Lease struct {
+	LockID string
+	// Token はロックの所有者を識別する。所有者のみがロックを延長・解放できる
+	Token string
+	// TTL を過ぎても延長されないロックは解放されたものとみなす
+	TTL time.Duration
+}
+
+// Acquire は他の所有者がロックを取得している場合に None を返す。
+type Acquire = func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*Lease]]
+
+// Renew はロックの期限を TTL だけ延長する。期限切れなどで所有者でなくなっていた場合は false を返す。
+type Renew = func(ctx context.Context, lease *Lease) mo.Result[bool]
+
+// Release は所有者でない場合は何もしない。
+type Release = func(ctx context.Context, lease *Lease) error
+
+// FailurePolicy はロックの取得でエラーになった場合の扱いを表す。
+type FailurePolicy string
+
+const (
+	// 実行を失敗させる
+	FailurePolicyFail FailurePolicy = "fail"
+	// ロックを取得せずに実行する
+	FailurePolicyProceed FailurePolicy = "proceed"
+	// Fallback のロックを取得して実行する
+	FailurePolicyFallback FailurePolicy = "fallback"
+)
+
+func ParseFailurePolicy(s string) (FailurePolicy, error) {
+	switch FailurePolicy(s) {
+	case FailurePolicyFail, FailurePolicyProceed, FailurePolicyFallback:
+		return FailurePolicy(s), nil
+	}
+	return "", fmt.Errorf("unsupported lock failure policy: %s", s)
+}
+
+type Locker struct {
+	Acquire Acquire
+	Renew   Renew
+	Release Release
+	TTL     time.Duration
+	// NOTE: 他の実行がロックを取得済みの場合は FailurePolicy に関わらず失敗させる
+	FailurePolicy FailurePolicy
+	// FailurePolicy が FailurePolicyFallback の場合に取得するロック
+	Fallback *Locker
+}
@@ -7,3 +7,5 @@ import (
 )
 
 type NotifyAnalysisReport = func(context.Context, *model.AnalysisReport) error
+
+type NotifyDigestReport = func(context.Context, *model.DigestReport) error
@@ -0,0 +1,12 @@
+package pause
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+type LoadPausePeriods = func(context.Context) mo.Result[[]model.PausePeriod]
+
+type SavePausePeriods = func(context.Context, []model.PausePeriod) error
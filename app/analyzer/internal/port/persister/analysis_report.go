@@ -2,8 +2,28 @@ package persister
 
 import (
 	"context"
+	"time"
 
+	"github.com/samber/mo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 )
 
 type PersistAnalysisReport = func(context.Context, *model.AnalysisReport) error
+
+// AnalysisReportPersister はレポートの保存先を表す。
+// Required が true の保存先に保存できなかった場合は実行を失敗させ、false の場合はベストエフォートで保存する。
+type AnalysisReportPersister struct {
+	Name     string
+	Required bool
+	// Primary は FindAnalysisReport が参照する保存先かどうかを表す。
+	// Primary の保存先には、他の必須の保存先に全て保存できた場合のみ保存する
+	Primary bool
+	Persist PersistAnalysisReport
+}
+
+// FindAnalysisReport は now と同じ日に保存された goal のレポートのうち最新のものを返す。
+type FindAnalysisReport = func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]]
+
+// ListAnalysisReports は from から to までの各日に保存された goal のレポートを日付の古い順に返す。
+// from と to は JST の日付とし、to の日も対象に含む。
+type ListAnalysisReports = func(ctx context.Context, goal model.GoalType, from, to time.Time) mo.Result[[]*model.AnalysisReport]
@@ -0,0 +1,9 @@
+package persister
+
+import (
+	"context"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+type PersistDigestReport = func(context.Context, *model.DigestReport) error
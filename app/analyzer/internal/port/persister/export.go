@@ -0,0 +1,16 @@
+package persister
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+)
+
+type CompactedExport struct {
+	Key  string
+	Rows int
+}
+
+// CompactAnalysisReportExports は from から to までの日付に書き出したレポートを 1 つのファイルにまとめる。
+type CompactAnalysisReportExports = func(ctx context.Context, from, to time.Time) mo.Result[*CompactedExport]
@@ -0,0 +1,30 @@
+package persister
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+)
+
+type MaintenancePolicy struct {
+	Now time.Time
+	// この日時より前の日付のレポートを削除またはアーカイブする。ゼロ値の場合は保持期間を適用しない
+	RetentionCutoff time.Time
+	// true の場合は削除せずにアーカイブ用の prefix に移動する
+	Archive bool
+	// true の場合は変更せずに対象の件数のみを数える
+	DryRun bool
+}
+
+type MaintenanceResult struct {
+	// 月次のファイルにまとめた日次のファイル数
+	Compacted int
+	// 作成または更新した月次のファイル数
+	MonthlyFiles int
+	Deleted      int
+	Archived     int
+}
+
+// MaintainReports は保持期間を過ぎたレポートを削除またはアーカイブし、前月以前の日次のレポートを月次のファイルにまとめる。
+type MaintainReports = func(ctx context.Context, policy *MaintenancePolicy) mo.Result[*MaintenanceResult]
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/samber/mo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/model"
@@ -9,9 +10,39 @@ import (
 
 type AnalyzeInput struct {
 	Goal model.GoalType
+	// 0 の場合はポイント目標を評価しない
+	TargetPoints int
+	// true の場合は同じ日に同じ目標のレポートが保存済みでも再実行する
+	Force bool
 }
 type AnalyzeOutput struct {
-	IsGoalAchieved bool
+	IsGoalAchieved       bool
+	EarnedPoints         int
+	IsPointsGoalAchieved bool
+	DegradedSources      []string
+	Paused               bool
+	// 保存に失敗したベストエフォートの保存先
+	FailedPersisters []string
+	LockPath         LockPath
 }
 
 type Analyze = func(context.Context, *AnalyzeInput) mo.Result[*AnalyzeOutput]
+
+// BackfillInput の From と To は JST の日付の 00:00 とし、To の日も対象に含む。
+type BackfillInput struct {
+	From time.Time
+	To   time.Time
+	Goal model.GoalType
+	// 0 の場合はポイント目標を評価しない
+	TargetPoints int
+}
+type BackfillOutput struct {
+	// レポートを保存した日数
+	Days int
+	// 目標の期間が過去の全てのエントリを返さないフェッチ元の返したエントリより前から始まるため、レポートを保存しなかった日数
+	SkippedDays int
+	// 過去の全てのエントリを返さないフェッチ元
+	IncompleteSources []string
+}
+
+type Backfill = func(context.Context, *BackfillInput) mo.Result[*BackfillOutput]
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+type DigestInput struct {
+	// 集計する期間
+	Period model.DigestPeriod
+}
+type DigestOutput struct {
+	Entries         int
+	PreviousEntries int
+	LockPath        LockPath
+}
+
+type Digest = func(context.Context, *DigestInput) mo.Result[*DigestOutput]
@@ -0,0 +1,20 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+)
+
+// CompactExportInput の From と To は JST の日付の 00:00 とし、To の日も対象に含む。
+type CompactExportInput struct {
+	From time.Time
+	To   time.Time
+}
+type CompactExportOutput struct {
+	Key  string
+	Rows int
+}
+
+type CompactExport = func(context.Context, *CompactExportInput) mo.Result[*CompactExportOutput]
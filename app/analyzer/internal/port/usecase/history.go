@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+// HistoryInput の From と To は JST の日付の 00:00 とし、To の日も対象に含む。
+type HistoryInput struct {
+	Goal model.GoalType
+	From time.Time
+	To   time.Time
+}
+type HistoryOutput struct {
+	// 日付の古い順
+	Reports []*model.AnalysisReport
+}
+
+type History = func(context.Context, *HistoryInput) mo.Result[*HistoryOutput]
@@ -0,0 +1,11 @@
+package usecase
+
+// LockPath は重複実行を防ぐロックをどのように取得して実行したかを表す。
+type LockPath string
+
+const (
+	LockPathPrimary  LockPath = "primary"
+	LockPathFallback LockPath = "fallback"
+	// ロックを取得できなかったが、ポリシーに従ってロックなしで実行した
+	LockPathUnlocked LockPath = "unlocked"
+)
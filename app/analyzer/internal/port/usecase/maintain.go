@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/samber/mo"
+)
+
+type MaintainInput struct {
+	// 0 の場合は保持期間を適用せず、月次のファイルへのまとめのみを行う
+	RetentionDays int
+	// true の場合は保持期間を過ぎたレポートを削除せずにアーカイブする
+	Archive bool
+	DryRun  bool
+}
+type MaintainOutput struct {
+	Compacted    int
+	MonthlyFiles int
+	Deleted      int
+	Archived     int
+}
+
+type Maintain = func(context.Context, *MaintainInput) mo.Result[*MaintainOutput]
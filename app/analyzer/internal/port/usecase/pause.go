@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+)
+
+type AddPausePeriodInput struct {
+	Period model.PausePeriod
+}
+
+type RemovePausePeriodInput struct {
+	Start time.Time
+	End   time.Time
+}
+
+// PausePeriodsOutput は保存されている休止期間を開始日の昇順で返す。
+// NOTE: 環境変数で設定された休止期間は含まない
+type PausePeriodsOutput struct {
+	Periods []model.PausePeriod
+}
+
+type AddPausePeriod = func(context.Context, *AddPausePeriodInput) mo.Result[*PausePeriodsOutput]
+
+type RemovePausePeriod = func(context.Context, *RemovePausePeriodInput) mo.Result[*PausePeriodsOutput]
+
+type ListPausePeriods = func(context.Context) mo.Result[*PausePeriodsOutput]
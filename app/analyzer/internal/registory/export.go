@@ -0,0 +1,17 @@
+package registory
+
+import (
+	"context"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/s3"
+	usecaseport "github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	usecaseadapter "github.com/ss49919201/keeput/app/analyzer/internal/usecase"
+)
+
+func NewCompactExportUsecase(ctx context.Context) (usecaseport.CompactExport, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewCompactExport(s3.NewCompactAnalysisReportExports(awsConfig)), nil
+}
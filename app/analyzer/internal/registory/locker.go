@@ -0,0 +1,77 @@
+package registory
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/cfworker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/dynamodb"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/flock"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/memory"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/redis"
+	appconfig "github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+)
+
+// NOTE: 同じプロセス内のユースケースで取得済みのロックを共有する
+var memoryLocks = sync.OnceValue(memory.NewLocks)
+
+var flockLocks = sync.OnceValue(func() *flock.Locks {
+	return flock.NewLocks(appconfig.LockerFileDir())
+})
+
+// NOTE: 同じプロセス内のユースケースで Redis への接続を共有する
+var redisLocks = sync.OnceValues(func() (*redis.Locks, error) {
+	return redis.NewLocks(appconfig.LockerRedisURL())
+})
+
+// newLocker は設定されているロックを返す。
+func newLocker(awsConfig aws.Config) (locker.Locker, error) {
+	lock, err := newLockerOf(appconfig.Locker(), awsConfig)
+	if err != nil {
+		return locker.Locker{}, err
+	}
+	lock.FailurePolicy, err = locker.ParseFailurePolicy(appconfig.LockerFailurePolicy())
+	if err != nil {
+		return locker.Locker{}, err
+	}
+	if lock.FailurePolicy != locker.FailurePolicyFallback {
+		return lock, nil
+	}
+
+	if appconfig.LockerFallback() == "" || appconfig.LockerFallback() == appconfig.Locker() {
+		return locker.Locker{}, fmt.Errorf("LOCKER_FALLBACK must be set to a locker other than %s", appconfig.Locker())
+	}
+	fallback, err := newLockerOf(appconfig.LockerFallback(), awsConfig)
+	if err != nil {
+		return locker.Locker{}, err
+	}
+	lock.Fallback = &fallback
+	return lock, nil
+}
+
+func newLockerOf(kind string, awsConfig aws.Config) (locker.Locker, error) {
+	lock := locker.Locker{TTL: appconfig.LockerTTL()}
+	switch kind {
+	case appconfig.LockerCloudflareWorker:
+		url, apiKey := appconfig.LockerURLCloudflareWorker(), appconfig.LockerAPIKeyCloudflareWorker()
+		lock.Acquire, lock.Renew, lock.Release = cfworker.NewAcquire(url, apiKey), cfworker.NewRenew(url, apiKey), cfworker.NewRelease(url, apiKey)
+	case appconfig.LockerMemory:
+		lock.Acquire, lock.Renew, lock.Release = memory.NewAcquire(memoryLocks()), memory.NewRenew(memoryLocks()), memory.NewRelease(memoryLocks())
+	case appconfig.LockerFile:
+		lock.Acquire, lock.Renew, lock.Release = flock.NewAcquire(flockLocks()), flock.NewRenew(flockLocks()), flock.NewRelease(flockLocks())
+	case appconfig.LockerDynamoDB:
+		locks := dynamodb.NewLocks(awsConfig, appconfig.LockerDynamoDBTable())
+		lock.Acquire, lock.Renew, lock.Release = dynamodb.NewAcquire(locks), dynamodb.NewRenew(locks), dynamodb.NewRelease(locks)
+	case appconfig.LockerRedis:
+		locks, err := redisLocks()
+		if err != nil {
+			return locker.Locker{}, fmt.Errorf("failed to parse LOCKER_REDIS_URL: %w", err)
+		}
+		lock.Acquire, lock.Renew, lock.Release = redis.NewAcquire(locks), redis.NewRenew(locks), redis.NewRelease(locks)
+	default:
+		return locker.Locker{}, fmt.Errorf("unknown locker: %s", kind)
+	}
+	return lock, nil
+}
@@ -0,0 +1,20 @@
+package registory
+
+import (
+	"context"
+
+	usecaseport "github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	usecaseadapter "github.com/ss49919201/keeput/app/analyzer/internal/usecase"
+)
+
+func NewMaintainUsecase(ctx context.Context) (usecaseport.Maintain, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	maintainers, err := newMaintainReports(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewMaintain(maintainers), nil
+}
@@ -0,0 +1,41 @@
+package registory
+
+import (
+	"context"
+
+	pauses3 "github.com/ss49919201/keeput/app/analyzer/internal/adapter/pause/s3"
+	usecaseport "github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	usecaseadapter "github.com/ss49919201/keeput/app/analyzer/internal/usecase"
+)
+
+func NewAddPausePeriodUsecase(ctx context.Context) (usecaseport.AddPausePeriod, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewAddPausePeriod(
+		pauses3.NewLoadPausePeriods(awsConfig),
+		pauses3.NewSavePausePeriods(awsConfig),
+	), nil
+}
+
+func NewRemovePausePeriodUsecase(ctx context.Context) (usecaseport.RemovePausePeriod, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewRemovePausePeriod(
+		pauses3.NewLoadPausePeriods(awsConfig),
+		pauses3.NewSavePausePeriods(awsConfig),
+	), nil
+}
+
+func NewListPausePeriodsUsecase(ctx context.Context) (usecaseport.ListPausePeriods, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewListPausePeriods(
+		pauses3.NewLoadPausePeriods(awsConfig),
+	), nil
+}
@@ -0,0 +1,114 @@
+package registory
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/file"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/postgres"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/s3"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/sqlite"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/webhook"
+	appconfig "github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+type reportPersisters struct {
+	persistAnalysisReport persister.PersistAnalysisReport
+	findAnalysisReport    persister.FindAnalysisReport
+	listAnalysisReports   persister.ListAnalysisReports
+	persistDigestReport   persister.PersistDigestReport
+}
+
+// NOTE: 同じプロセス内のユースケースで 1 つのデータベースファイルを共有する
+var sqliteDB = sync.OnceValues(func() (*sql.DB, error) {
+	return sqlite.Open(context.Background(), appconfig.SQLitePath())
+})
+
+var postgresPool = sync.OnceValues(func() (*pgxpool.Pool, error) {
+	return postgres.Open(context.Background(), appconfig.PostgresDSN())
+})
+
+// newReportPersisters は設定されている保存先のレポートの保存・取得を返す。
+func newReportPersisters(awsConfig aws.Config) (*reportPersisters, error) {
+	switch appconfig.Persister() {
+	case appconfig.PersisterS3:
+		return &reportPersisters{
+			persistAnalysisReport: s3.NewPersistAnalysisReport(awsConfig),
+			findAnalysisReport:    s3.NewFindAnalysisReport(awsConfig),
+			listAnalysisReports:   s3.NewListAnalysisReports(awsConfig),
+			persistDigestReport:   s3.NewPersistDigestReport(awsConfig),
+		}, nil
+	case appconfig.PersisterSQLite:
+		db, err := sqliteDB()
+		if err != nil {
+			return nil, err
+		}
+		return &reportPersisters{
+			persistAnalysisReport: sqlite.NewPersistAnalysisReport(db),
+			findAnalysisReport:    sqlite.NewFindAnalysisReport(db),
+			listAnalysisReports:   sqlite.NewListAnalysisReports(db),
+			persistDigestReport:   sqlite.NewPersistDigestReport(db),
+		}, nil
+	case appconfig.PersisterPostgres:
+		pool, err := postgresPool()
+		if err != nil {
+			return nil, err
+		}
+		return &reportPersisters{
+			persistAnalysisReport: postgres.NewPersistAnalysisReport(pool),
+			findAnalysisReport:    postgres.NewFindAnalysisReport(pool),
+			listAnalysisReports:   postgres.NewListAnalysisReports(pool),
+			persistDigestReport:   postgres.NewPersistDigestReport(pool),
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown persister: %s", appconfig.Persister())
+	}
+}
+
+// analysisReportPersisters は PERSISTER の保存先に加え、設定されている追加の保存先を返す。
+func (p *reportPersisters) analysisReportPersisters() []persister.AnalysisReportPersister {
+	persisters := []persister.AnalysisReportPersister{
+		{
+			Name:     appconfig.Persister(),
+			Required: appconfig.PersisterRequired(),
+			Primary:  true,
+			Persist:  p.persistAnalysisReport,
+		},
+	}
+	if dir := appconfig.FilePersisterDir(); dir != "" {
+		persisters = append(persisters, persister.AnalysisReportPersister{
+			Name:     "file",
+			Required: appconfig.FilePersisterRequired(),
+			Persist:  file.NewPersistAnalysisReport(dir),
+		})
+	}
+	if url := appconfig.WebhookPersisterURL(); url != "" {
+		persisters = append(persisters, persister.AnalysisReportPersister{
+			Name:     "webhook",
+			Required: appconfig.WebhookPersisterRequired(),
+			Persist:  webhook.NewPersistAnalysisReport(url),
+		})
+	}
+	return persisters
+}
+
+// newMaintainReports はファイルとして保存する保存先のメンテナンスを返す。
+// NOTE: データベースの保存先はレポートごとのファイルを持たないため対象外とする
+func newMaintainReports(awsConfig aws.Config) ([]persister.MaintainReports, error) {
+	var maintainers []persister.MaintainReports
+	if appconfig.Persister() == appconfig.PersisterS3 {
+		maintainers = append(maintainers, s3.NewMaintainReports(awsConfig))
+	}
+	if dir := appconfig.FilePersisterDir(); dir != "" {
+		maintainers = append(maintainers, file.NewMaintainReports(dir))
+	}
+	if len(maintainers) == 0 {
+		return nil, fmt.Errorf("no persister supports maintenance: %s", appconfig.Persister())
+	}
+	return maintainers, nil
+}
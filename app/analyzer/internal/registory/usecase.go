@@ -2,34 +2,251 @@ package registory
 
 import (
 	"context"
+	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/samber/lo"
+	breakers3 "github.com/ss49919201/keeput/app/analyzer/internal/adapter/circuitbreaker/s3"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/circuitbreaker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/feed"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/gitrepo"
 	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/hatena"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/markdown"
+	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/slide"
 	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/fetcher/zenn"
-	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/locker/cfworker"
 	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/notifier/discord"
-	"github.com/ss49919201/keeput/app/analyzer/internal/adapter/persister/s3"
+	pauseconfig "github.com/ss49919201/keeput/app/analyzer/internal/adapter/pause/config"
+	pauses3 "github.com/ss49919201/keeput/app/analyzer/internal/adapter/pause/s3"
+	appconfig "github.com/ss49919201/keeput/app/analyzer/internal/config"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
 	usecaseport "github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
 	usecaseadapter "github.com/ss49919201/keeput/app/analyzer/internal/usecase"
 	"go.opentelemetry.io/contrib/instrumentation/github.com/aws/aws-sdk-go-v2/otelaws"
 )
 
-func NewAnalyzeUsecase(ctx context.Context) (usecaseport.Analyze, error) {
+func loadAWSConfig(ctx context.Context) (aws.Config, error) {
 	awsConfig, err := config.LoadDefaultConfig(ctx)
 	if err != nil {
-		return nil, err
+		return aws.Config{}, err
 	}
 	otelaws.AppendMiddlewares(&awsConfig.APIOptions)
+	return awsConfig, nil
+}
+
+func NewAnalyzeUsecase(ctx context.Context) (usecaseport.Analyze, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loadState := breakers3.NewLoadState(awsConfig)
+	saveState := breakers3.NewSaveState(awsConfig)
+
+	// NOTE: AtomPub の認証情報が設定されている場合は RSS の代わりに AtomPub から取得する。
+	// 最も長い目標期間のエントリが揃った時点で打ち切る
+	fetchHatenaEntries := hatena.NewFetchEntries()
+	if appconfig.HatenaAtomPubEnabled() {
+		fetchHatenaEntries = hatena.NewAtomPubFetchRecentEntries(func(now time.Time) time.Time {
+			return model.GoalPeriodStart(now, model.GoalTypeRecentMonth)
+		})
+	}
+
+	entryFetchers, err := newEntryFetchers(fetchHatenaEntries, func(source string, fetch fetcher.FetchEntries) fetcher.FetchEntries {
+		return circuitbreaker.NewFetchEntries(source, fetch, loadState, saveState)
+	})
+	if err != nil {
+		return nil, err
+	}
+	eligibilityRules, err := newEligibilityRules()
+	if err != nil {
+		return nil, err
+	}
+	pauseMode, err := model.ParsePauseMode(appconfig.PauseMode())
+	if err != nil {
+		return nil, err
+	}
+	persisters, err := newReportPersisters(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := newLocker(awsConfig)
+	if err != nil {
+		return nil, err
+	}
 
 	return usecaseadapter.NewAnalyze(
-		[]fetcher.FetchLatestEntry{
-			hatena.NewFetchLatestEntry(),
-			zenn.NewFetchLatestEntry(),
-		},
+		entryFetchers,
+		eligibilityRules,
+		pauseMode,
+		newPausePeriodLoaders(awsConfig),
 		discord.NewNotifyAnalysisReport(),
-		cfworker.NewAcquire(),
-		cfworker.NewRelease(),
-		s3.NewPersistAnalysisReport(awsConfig),
+		lock,
+		persisters.findAnalysisReport,
+		persisters.analysisReportPersisters(),
+	), nil
+}
+
+func NewBackfillUsecase(ctx context.Context) (usecaseport.Backfill, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: 過去のレポートを再構築するため、AtomPub が有効な場合は全てのエントリを取得する。
+	// 手動での実行を想定しているため、サーキットブレーカーは適用しない
+	hatenaSource := fetcher.EntrySource{Name: "hatena", Fetch: hatena.NewFetchEntries()}
+	if appconfig.HatenaAtomPubEnabled() {
+		hatenaSource = fetcher.EntrySource{Name: "hatena", Fetch: hatena.NewAtomPubFetchEntries(), Complete: true}
+	}
+
+	entrySources, err := newEntrySources(hatenaSource, func(_ string, fetch fetcher.FetchEntries) fetcher.FetchEntries {
+		return fetch
+	})
+	if err != nil {
+		return nil, err
+	}
+	eligibilityRules, err := newEligibilityRules()
+	if err != nil {
+		return nil, err
+	}
+	pauseMode, err := model.ParsePauseMode(appconfig.PauseMode())
+	if err != nil {
+		return nil, err
+	}
+	persisters, err := newReportPersisters(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecaseadapter.NewBackfill(
+		entrySources,
+		eligibilityRules,
+		pauseMode,
+		newPausePeriodLoaders(awsConfig),
+		persisters.analysisReportPersisters(),
+	), nil
+}
+
+func NewDigestUsecase(ctx context.Context) (usecaseport.Digest, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	loadState := breakers3.NewLoadState(awsConfig)
+	saveState := breakers3.NewSaveState(awsConfig)
+
+	// NOTE: 前期間と比較するため、最も長い期間の 2 期間分のエントリが揃った時点で打ち切る
+	fetchHatenaEntries := hatena.NewFetchEntries()
+	if appconfig.HatenaAtomPubEnabled() {
+		fetchHatenaEntries = hatena.NewAtomPubFetchRecentEntries(func(now time.Time) time.Time {
+			start := model.GoalPeriodStart(now, model.GoalTypeRecentMonth)
+			return start.Add(-date.BeginningOfDay(now).Sub(start))
+		})
+	}
+
+	entryFetchers, err := newEntryFetchers(fetchHatenaEntries, func(source string, fetch fetcher.FetchEntries) fetcher.FetchEntries {
+		return circuitbreaker.NewFetchEntries(source, fetch, loadState, saveState)
+	})
+	if err != nil {
+		return nil, err
+	}
+	persisters, err := newReportPersisters(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	lock, err := newLocker(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return usecaseadapter.NewDigest(
+		entryFetchers,
+		discord.NewNotifyDigestReport(),
+		lock,
+		persisters.persistDigestReport,
 	), nil
 }
+
+// newEntryFetchers は設定されているフェッチ元のフェッチャーを返す。
+// wrap はネットワーク経由で取得するフェッチ元にのみ適用する。
+func newEntryFetchers(fetchHatenaEntries fetcher.FetchEntries, wrap func(source string, fetch fetcher.FetchEntries) fetcher.FetchEntries) ([]fetcher.FetchEntries, error) {
+	sources, err := newEntrySources(fetcher.EntrySource{Name: "hatena", Fetch: fetchHatenaEntries}, wrap)
+	if err != nil {
+		return nil, err
+	}
+	return lo.Map(sources, func(source fetcher.EntrySource, _ int) fetcher.FetchEntries {
+		return source.Fetch
+	}), nil
+}
+
+// newEntrySources は設定されているフェッチ元を返す。
+// NOTE: フィードは直近のエントリのみを返すため、ローカルのファイルと hatena 以外は過去の全てのエントリを返さないものとする
+func newEntrySources(hatena fetcher.EntrySource, wrap func(source string, fetch fetcher.FetchEntries) fetcher.FetchEntries) ([]fetcher.EntrySource, error) {
+	feedSources, err := appconfig.FeedSources()
+	if err != nil {
+		return nil, err
+	}
+	remote := func(name string, fetch fetcher.FetchEntries) fetcher.EntrySource {
+		return fetcher.EntrySource{Name: name, Fetch: wrap(name, fetch)}
+	}
+
+	hatena.Fetch = wrap(hatena.Name, hatena.Fetch)
+	sources := []fetcher.EntrySource{
+		hatena,
+		remote("zenn", zenn.NewFetchEntries()),
+	}
+	for _, source := range feedSources {
+		sources = append(sources, remote("feed_"+source.ID, feed.NewFetchEntries(source)))
+	}
+
+	if appconfig.FeedURLSpeakerDeck() != "" {
+		sources = append(sources, remote("speakerdeck", slide.NewSpeakerDeckFetchEntries()))
+	}
+	if appconfig.FeedURLDocswell() != "" {
+		sources = append(sources, remote("docswell", slide.NewDocswellFetchEntries()))
+	}
+	// NOTE: ローカルの Markdown と Git リポジトリは CLI での実行を想定しており、ディレクトリが設定されている場合のみ対象とする
+	if appconfig.MarkdownDir() != "" {
+		sources = append(sources, fetcher.EntrySource{Name: "markdown", Fetch: markdown.NewFetchEntries(), Complete: true})
+	}
+	if appconfig.GitRepositoryDir() != "" {
+		sources = append(sources, fetcher.EntrySource{Name: "gitrepo", Fetch: gitrepo.NewFetchEntries(), Complete: true})
+	}
+	return sources, nil
+}
+
+func newEligibilityRules() (*model.EligibilityRules, error) {
+	excludedTitlePattern, err := appconfig.EligibilityExcludedTitlePattern()
+	if err != nil {
+		return nil, err
+	}
+	return &model.EligibilityRules{
+		MinCharacters:        appconfig.EligibilityMinCharacters(),
+		ExcludedTags:         appconfig.EligibilityExcludedTags(),
+		ExcludedTitlePattern: excludedTitlePattern,
+	}, nil
+}
+
+func newPausePeriodLoaders(awsConfig aws.Config) []pause.LoadPausePeriods {
+	return []pause.LoadPausePeriods{
+		pauseconfig.NewLoadPausePeriods(),
+		pauses3.NewLoadPausePeriods(awsConfig),
+	}
+}
+
+func NewHistoryUsecase(ctx context.Context) (usecaseport.History, error) {
+	awsConfig, err := loadAWSConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	persisters, err := newReportPersisters(awsConfig)
+	if err != nil {
+		return nil, err
+	}
+	return usecaseadapter.NewHistory(persisters.listAnalysisReports), nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"sync"
 	"time"
 
@@ -15,15 +16,16 @@ import (
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/notifier"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/metric"
 )
 
-func NewAnalyze(latestEntryFetchers []fetcher.FetchLatestEntry, notifyAnalysisReport notifier.NotifyAnalysisReport, acquireLock locker.Acquire, releaseLock locker.Release, persistAnalysisReport persister.PersistAnalysisReport) usecase.Analyze {
+func NewAnalyze(entryFetchers []fetcher.FetchEntries, eligibilityRules *model.EligibilityRules, pauseMode model.PauseMode, pausePeriodLoaders []pause.LoadPausePeriods, notifyAnalysisReport notifier.NotifyAnalysisReport, lock locker.Locker, findAnalysisReport persister.FindAnalysisReport, persisters []persister.AnalysisReportPersister) usecase.Analyze {
 	return func(ctx context.Context, in *usecase.AnalyzeInput) mo.Result[*usecase.AnalyzeOutput] {
-		return analyze(ctx, in, latestEntryFetchers, notifyAnalysisReport, acquireLock, releaseLock, persistAnalysisReport)
+		return analyze(ctx, in, entryFetchers, eligibilityRules, pauseMode, pausePeriodLoaders, notifyAnalysisReport, lock, findAnalysisReport, persisters)
 	}
 }
 
@@ -46,63 +48,53 @@ var (
 	})
 )
 
-func analyze(ctx context.Context, in *usecase.AnalyzeInput, latestEntryFetchers []fetcher.FetchLatestEntry, notifyAnalysisReport notifier.NotifyAnalysisReport, acquireLock locker.Acquire, releaseLock locker.Release, persistAnalysisReport persister.PersistAnalysisReport) mo.Result[*usecase.AnalyzeOutput] {
+func analyze(ctx context.Context, in *usecase.AnalyzeInput, entryFetchers []fetcher.FetchEntries, eligibilityRules *model.EligibilityRules, pauseMode model.PauseMode, pausePeriodLoaders []pause.LoadPausePeriods, notifyAnalysisReport notifier.NotifyAnalysisReport, lock locker.Locker, findAnalysisReport persister.FindAnalysisReport, persisters []persister.AnalysisReportPersister) mo.Result[*usecase.AnalyzeOutput] {
 	// NOTE: defer でのロック解放遅延を analyze のブロックで行いたいため、ロック処理は result.Pipe に含めない
 	lockID := lockIDPrefixAnalyze + ":" + appctx.GetNowOr(ctx, time.Now()).Format(time.DateOnly)
-	acquired, err := acquireLock(ctx, lockID).Get()
+	ctx, releaseLock, lockPath, err := acquireLock(ctx, lock, lockID)
 	if err != nil {
 		return mo.Err[*usecase.AnalyzeOutput](err)
 	}
-	if !acquired {
-		return mo.Err[*usecase.AnalyzeOutput](errors.New("lock already acquired"))
-	}
-	defer func() {
-		if err := releaseLock(ctx, lockID); err != nil {
-			slog.Warn("failed release lock")
+	defer releaseLock()
+
+	// NOTE: スケジューラーによる再試行で通知が重複しないよう、保存済みのレポートがある場合はその結果を返す。
+	// 保存済みのレポートは全ての必須の保存先に保存できた後に保存されるため、通知済みとみなせる
+	if !in.Force {
+		found, err := findAnalysisReport(ctx, in.Goal, appctx.GetNowOr(ctx, time.Now())).Get()
+		if err != nil {
+			slog.Warn("failed to find analysis report", slog.String("error", err.Error()))
+		} else if report, ok := found.Get(); ok {
+			slog.Info("analysis report already exists", slog.Time("generated_at", report.GeneratedAt))
+			out := newAnalyzeOutput(report)
+			out.LockPath = lockPath
+			return mo.Ok(out)
 		}
-	}()
+	}
 
+	pauseSetting := &model.PauseSetting{
+		Periods: loadPausePeriods(ctx, pausePeriodLoaders),
+		Mode:    pauseMode,
+	}
+
+	var failedPersisters []string
 	return result.Pipe5(
-		func() mo.Result[mo.Option[*model.Entry]] {
-			resultCh := make(chan mo.Result[mo.Option[*model.Entry]], len(latestEntryFetchers))
-			for _, fetch := range latestEntryFetchers {
-				go func() {
-					resultCh <- fetch(ctx)
-				}()
-			}
-			entries := make([]*model.Entry, 0, len(latestEntryFetchers))
-			var errs []error
-			for range len(latestEntryFetchers) {
-				entry, err := (<-resultCh).Get()
-				if err != nil {
-					errs = append(errs, err)
-					continue
-				}
-				if entry.IsNone() {
-					continue
-				}
-				entries = append(entries, entry.MustGet())
-			}
-			if len(errs) == len(latestEntryFetchers) {
-				return mo.Err[mo.Option[*model.Entry]](fmt.Errorf("all entry fetch operations failed: %w", errors.Join(errs...)))
-			} else if len(errs) > 0 && len(errs) < len(latestEntryFetchers) {
-				slog.Warn("some entry fetch operations failed", slog.String("error", errors.Join(errs...).Error()))
-			}
-			return mo.Ok(model.Latest(entries))
-		}(),
-		result.Map(func(entry mo.Option[*model.Entry]) *model.AnalysisReport {
-			return model.Analyze(entry, appctx.GetNowOr(ctx, time.Now()), in.Goal)
+		fetchEntries(ctx, entryFetchers),
+		result.Map(func(fetched *fetchedEntries) *model.AnalysisReport {
+			report := model.Analyze(fetched.entries, appctx.GetNowOr(ctx, time.Now()), in.Goal, in.TargetPoints, eligibilityRules, pauseSetting)
+			report.DegradedSources = fetched.degradedSources
+			return report
 		}),
-		result.Map(func(report *model.AnalysisReport) *model.AnalysisReport {
-			if err := persistAnalysisReport(ctx, report); err != nil {
-				slog.Warn("failed to persist analysis report", slog.String("error", err.Error()))
+		// NOTE: 必須の保存先に保存できなかった場合は、再試行で改めて通知されるため、通知が重複しないよう通知前に失敗させる
+		result.FlatMap(func(report *model.AnalysisReport) mo.Result[*model.AnalysisReport] {
+			failed, err := persistAnalysisReport(ctx, persisters, report)
+			if err != nil {
+				return mo.Err[*model.AnalysisReport](err)
 			}
-			return report
+			failedPersisters = failed
+			return mo.Ok(report)
 		}),
 		result.Map(func(report *model.AnalysisReport) *model.AnalysisReport {
-			if err := notifyAnalysisReport(ctx, report); err != nil {
-				slog.Warn("failed to notify analysis report", "error", err)
-			}
+			notify(ctx, notifyAnalysisReport, report)
 			return report
 		}),
 		result.Map(func(report *model.AnalysisReport) *model.AnalysisReport {
@@ -112,9 +104,82 @@ func analyze(ctx context.Context, in *usecase.AnalyzeInput, latestEntryFetchers
 			return report
 		}),
 		result.Map(func(report *model.AnalysisReport) *usecase.AnalyzeOutput {
-			return &usecase.AnalyzeOutput{
-				IsGoalAchieved: report.IsGoalAchieved,
-			}
+			out := newAnalyzeOutput(report)
+			out.FailedPersisters = failedPersisters
+			out.LockPath = lockPath
+			return out
 		}),
 	)
 }
+
+// notify はレポートを通知する。
+// NOTE: 通知に失敗しても分析結果は保存済みのため、実行は失敗させない
+func notify(ctx context.Context, notifyAnalysisReport notifier.NotifyAnalysisReport, report *model.AnalysisReport) {
+	if err := notifyAnalysisReport(ctx, report); err != nil {
+		slog.Warn("failed to notify analysis report", "error", err)
+	}
+}
+
+func newAnalyzeOutput(report *model.AnalysisReport) *usecase.AnalyzeOutput {
+	return &usecase.AnalyzeOutput{
+		IsGoalAchieved:       report.IsGoalAchieved,
+		EarnedPoints:         report.EarnedPoints,
+		IsPointsGoalAchieved: report.IsPointsGoalAchieved,
+		DegradedSources:      report.DegradedSources,
+		Paused:               report.Paused,
+	}
+}
+
+// loadPausePeriods は全ての取得元から休止期間を取得する。
+// NOTE: 休止期間を取得できなくても目標の評価は継続する
+func loadPausePeriods(ctx context.Context, pausePeriodLoaders []pause.LoadPausePeriods) []model.PausePeriod {
+	var periods []model.PausePeriod
+	for _, load := range pausePeriodLoaders {
+		loaded, err := load(ctx).Get()
+		if err != nil {
+			slog.Warn("failed to load pause periods", slog.String("error", err.Error()))
+			continue
+		}
+		periods = append(periods, loaded...)
+	}
+	return periods
+}
+
+type fetchedEntries struct {
+	entries         []*model.Entry
+	degradedSources []string
+}
+
+func fetchEntries(ctx context.Context, entryFetchers []fetcher.FetchEntries) mo.Result[*fetchedEntries] {
+	resultCh := make(chan mo.Result[[]*model.Entry], len(entryFetchers))
+	for _, fetch := range entryFetchers {
+		go func() {
+			resultCh <- fetch(ctx)
+		}()
+	}
+	var entries []*model.Entry
+	var degradedSources []string
+	var errs []error
+	for range len(entryFetchers) {
+		fetched, err := (<-resultCh).Get()
+		if err != nil {
+			var degradedErr *fetcher.DegradedError
+			if errors.As(err, &degradedErr) {
+				degradedSources = append(degradedSources, degradedErr.Source)
+			}
+			errs = append(errs, err)
+			continue
+		}
+		entries = append(entries, fetched...)
+	}
+	if len(errs) == len(entryFetchers) {
+		return mo.Err[*fetchedEntries](fmt.Errorf("all entry fetch operations failed: %w", errors.Join(errs...)))
+	} else if len(errs) > 0 && len(errs) < len(entryFetchers) {
+		slog.Warn("some entry fetch operations failed", slog.String("error", errors.Join(errs...).Error()))
+	}
+	slices.Sort(degradedSources)
+	return mo.Ok(&fetchedEntries{
+		entries:         entries,
+		degradedSources: degradedSources,
+	})
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/notifier"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
 	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
 	"github.com/stretchr/testify/assert"
@@ -19,10 +21,13 @@ import (
 
 func TestAnalyze(t *testing.T) {
 	type args struct {
-		NewLatestEntryFetchers   func(t *testing.T) []fetcher.FetchLatestEntry
+		NewEntryFetchers         func(t *testing.T) []fetcher.FetchEntries
+		eligibilityRules         *model.EligibilityRules
+		pausePeriodLoaders       []pause.LoadPausePeriods
 		NewNotifyAnalysisReport  func(t *testing.T) notifier.NotifyAnalysisReport
 		NewAcquireLock           func(t *testing.T) locker.Acquire
 		NewReleaseLock           func(t *testing.T) locker.Release
+		NewFindAnalysisReport    func(t *testing.T) persister.FindAnalysisReport
 		NewPersistAnalysisReport func(t *testing.T) persister.PersistAnalysisReport
 
 		ctx   context.Context
@@ -36,51 +41,75 @@ func TestAnalyze(t *testing.T) {
 		{
 			"return results of achieving goal",
 			args{
-				NewLatestEntryFetchers: func(t *testing.T) []fetcher.FetchLatestEntry {
-					return []fetcher.FetchLatestEntry{
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Ok(mo.Some(&model.Entry{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{{
 								Title:       "Go 言語の slice について",
 								Body:        "Go 言語の slice は参照型です。気をつけましょう。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
-							}))
+							}})
 						},
 					}
 				},
 				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: true,
 							LatestEntry: mo.Some(&model.Entry{
 								Title:       "Go 言語の slice について",
 								Body:        "Go 言語の slice は参照型です。気をつけましょう。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
 							}),
+							EarnedPoints: 1,
+							Metrics: model.ContentMetricsSummary{
+								Entries:           1,
+								AverageCharacters: 26,
+								ContentMetrics: model.ContentMetrics{
+									Characters:         26,
+									ReadingTimeMinutes: 1,
+								},
+							},
 						}, report)
 						return nil
 					}
 				},
 				NewAcquireLock: func(t *testing.T) locker.Acquire {
-					return func(ctx context.Context, lockID string) mo.Result[bool] {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
 						assert.Equal(t, "usecase:analyze:2025-01-10", lockID)
-						return mo.Ok(true)
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
 					}
 				},
 				NewReleaseLock: func(t *testing.T) locker.Release {
-					return func(ctx context.Context, lockID string) error {
-						assert.Equal(t, "usecase:analyze:2025-01-10", lockID)
+					return func(ctx context.Context, lease *locker.Lease) error {
+						assert.Equal(t, "usecase:analyze:2025-01-10", lease.LockID)
 						return nil
 					}
 				},
 				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: true,
 							LatestEntry: mo.Some(&model.Entry{
 								Title:       "Go 言語の slice について",
 								Body:        "Go 言語の slice は参照型です。気をつけましょう。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
 							}),
+							EarnedPoints: 1,
+							Metrics: model.ContentMetricsSummary{
+								Entries:           1,
+								AverageCharacters: 26,
+								ContentMetrics: model.ContentMetrics{
+									Characters:         26,
+									ReadingTimeMinutes: 1,
+								},
+							},
 						}, report)
 						return nil
 					}
@@ -92,21 +121,26 @@ func TestAnalyze(t *testing.T) {
 			},
 			mo.Ok(&usecase.AnalyzeOutput{
 				IsGoalAchieved: true,
+				EarnedPoints:   1,
+				LockPath:       usecase.LockPathPrimary,
 			}),
 		},
 		{
 			"return results of not achieving goal",
 			args{
-				NewLatestEntryFetchers: func(t *testing.T) []fetcher.FetchLatestEntry {
-					return []fetcher.FetchLatestEntry{
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Ok(mo.None[*model.Entry]())
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{})
 						},
 					}
 				},
 				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: false,
 							LatestEntry:    mo.None[*model.Entry](),
 						}, report)
@@ -114,20 +148,23 @@ func TestAnalyze(t *testing.T) {
 					}
 				},
 				NewAcquireLock: func(t *testing.T) locker.Acquire {
-					return func(ctx context.Context, lockID string) mo.Result[bool] {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
 						assert.Equal(t, "usecase:analyze:2025-01-10", lockID)
-						return mo.Ok(true)
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
 					}
 				},
 				NewReleaseLock: func(t *testing.T) locker.Release {
-					return func(ctx context.Context, lockID string) error {
-						assert.Equal(t, "usecase:analyze:2025-01-10", lockID)
+					return func(ctx context.Context, lease *locker.Lease) error {
+						assert.Equal(t, "usecase:analyze:2025-01-10", lease.LockID)
 						return nil
 					}
 				},
 				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: false,
 							LatestEntry:    mo.None[*model.Entry](),
 						}, report)
@@ -141,18 +178,19 @@ func TestAnalyze(t *testing.T) {
 			},
 			mo.Ok(&usecase.AnalyzeOutput{
 				IsGoalAchieved: false,
+				LockPath:       usecase.LockPathPrimary,
 			}),
 		},
 		{
-			"return error when all latestEntryFetchers fail",
+			"return error when all entryFetchers fail",
 			args{
-				NewLatestEntryFetchers: func(t *testing.T) []fetcher.FetchLatestEntry {
-					return []fetcher.FetchLatestEntry{
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Err[mo.Option[*model.Entry]](assert.AnError)
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Err[[]*model.Entry](assert.AnError)
 						},
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Err[mo.Option[*model.Entry]](assert.AnError)
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Err[[]*model.Entry](assert.AnError)
 						},
 					}
 				},
@@ -163,12 +201,12 @@ func TestAnalyze(t *testing.T) {
 					}
 				},
 				NewAcquireLock: func(t *testing.T) locker.Acquire {
-					return func(ctx context.Context, lockID string) mo.Result[bool] {
-						return mo.Ok(true)
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
 					}
 				},
 				NewReleaseLock: func(t *testing.T) locker.Release {
-					return func(ctx context.Context, lockID string) error {
+					return func(ctx context.Context, lease *locker.Lease) error {
 						return nil
 					}
 				},
@@ -186,54 +224,78 @@ func TestAnalyze(t *testing.T) {
 			mo.Err[*usecase.AnalyzeOutput](assert.AnError),
 		},
 		{
-			"continue processing when some latestEntryFetchers fail",
+			"continue processing when some entryFetchers fail",
 			args{
-				NewLatestEntryFetchers: func(t *testing.T) []fetcher.FetchLatestEntry {
-					return []fetcher.FetchLatestEntry{
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Ok(mo.Some(&model.Entry{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{{
 								Title:       "Javaについて",
 								Body:        "JavaはJVMで動作します。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
-							}))
+							}})
 						},
-						func(ctx context.Context) mo.Result[mo.Option[*model.Entry]] {
-							return mo.Err[mo.Option[*model.Entry]](assert.AnError)
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Err[[]*model.Entry](assert.AnError)
 						},
 					}
 				},
 				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: true,
 							LatestEntry: mo.Some(&model.Entry{
 								Title:       "Javaについて",
 								Body:        "JavaはJVMで動作します。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
 							}),
+							EarnedPoints: 1,
+							Metrics: model.ContentMetricsSummary{
+								Entries:           1,
+								AverageCharacters: 15,
+								ContentMetrics: model.ContentMetrics{
+									Characters:         15,
+									ReadingTimeMinutes: 1,
+								},
+							},
 						}, report)
 						return nil
 					}
 				},
 				NewAcquireLock: func(t *testing.T) locker.Acquire {
-					return func(ctx context.Context, lockID string) mo.Result[bool] {
-						return mo.Ok(true)
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
 					}
 				},
 				NewReleaseLock: func(t *testing.T) locker.Release {
-					return func(ctx context.Context, lockID string) error {
+					return func(ctx context.Context, lease *locker.Lease) error {
 						return nil
 					}
 				},
 				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
 					return func(ctx context.Context, report *model.AnalysisReport) error {
 						assert.Equal(t, &model.AnalysisReport{
+							Goal:        model.GoalTypeRecentWeek,
+							GeneratedAt: time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+
 							IsGoalAchieved: true,
 							LatestEntry: mo.Some(&model.Entry{
 								Title:       "Javaについて",
 								Body:        "JavaはJVMで動作します。",
 								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
 							}),
+							EarnedPoints: 1,
+							Metrics: model.ContentMetricsSummary{
+								Entries:           1,
+								AverageCharacters: 15,
+								ContentMetrics: model.ContentMetrics{
+									Characters:         15,
+									ReadingTimeMinutes: 1,
+								},
+							},
 						}, report)
 						return nil
 					}
@@ -245,17 +307,312 @@ func TestAnalyze(t *testing.T) {
 			},
 			mo.Ok(&usecase.AnalyzeOutput{
 				IsGoalAchieved: true,
+				EarnedPoints:   1,
+				LockPath:       usecase.LockPathPrimary,
+			}),
+		},
+		{
+			"report degraded sources skipped by circuit breaker",
+			args{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{{
+								Title:       "Javaについて",
+								Body:        "JavaはJVMで動作します。",
+								PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+							}})
+						},
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Err[[]*model.Entry](&fetcher.DegradedError{Source: "zenn"})
+						},
+					}
+				},
+				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.Equal(t, []string{"zenn"}, report.DegradedSources)
+						return nil
+					}
+				},
+				NewAcquireLock: func(t *testing.T) locker.Acquire {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					}
+				},
+				NewReleaseLock: func(t *testing.T) locker.Release {
+					return func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					}
+				},
+				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.Equal(t, []string{"zenn"}, report.DegradedSources)
+						return nil
+					}
+				},
+				ctx: appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				input: &usecase.AnalyzeInput{
+					Goal: model.GoalTypeRecentWeek,
+				},
+			},
+			mo.Ok(&usecase.AnalyzeOutput{
+				IsGoalAchieved:  true,
+				EarnedPoints:    1,
+				DegradedSources: []string{"zenn"},
+				LockPath:        usecase.LockPathPrimary,
+			}),
+		},
+		{
+			"exclude ineligible entries from goal",
+			args{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{
+								{
+									Title:       "メモ",
+									Body:        "あとで書く",
+									PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+								},
+								{
+									Title:       "Javaについて",
+									Body:        "JavaはJVMで動作します。",
+									PublishedAt: time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC),
+								},
+							})
+						},
+					}
+				},
+				eligibilityRules: &model.EligibilityRules{
+					MinCharacters: 10,
+				},
+				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.Equal(t, "Javaについて", report.LatestEntry.MustGet().Title)
+						assert.Equal(t, []*model.ExcludedEntry{
+							{
+								Entry: &model.Entry{
+									Title:       "メモ",
+									Body:        "あとで書く",
+									PublishedAt: time.Date(2025, 1, 9, 10, 0, 0, 0, time.UTC),
+								},
+								Reason: model.ExclusionReasonTooShort,
+							},
+						}, report.ExcludedEntries)
+						return nil
+					}
+				},
+				NewAcquireLock: func(t *testing.T) locker.Acquire {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					}
+				},
+				NewReleaseLock: func(t *testing.T) locker.Release {
+					return func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					}
+				},
+				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.Len(t, report.ExcludedEntries, 1)
+						return nil
+					}
+				},
+				ctx: appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				input: &usecase.AnalyzeInput{
+					Goal: model.GoalTypeRecentWeek,
+				},
+			},
+			mo.Ok(&usecase.AnalyzeOutput{
+				IsGoalAchieved: true,
+				EarnedPoints:   1,
+				LockPath:       usecase.LockPathPrimary,
+			}),
+		},
+		{
+			"skip evaluation during pause",
+			args{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{{
+								Title:       "Javaについて",
+								Body:        "JavaはJVMで動作します。",
+								PublishedAt: time.Date(2024, 12, 1, 10, 0, 0, 0, time.UTC),
+							}})
+						},
+					}
+				},
+				pausePeriodLoaders: []pause.LoadPausePeriods{
+					func(ctx context.Context) mo.Result[[]model.PausePeriod] {
+						return mo.Err[[]model.PausePeriod](assert.AnError)
+					},
+					func(ctx context.Context) mo.Result[[]model.PausePeriod] {
+						period, err := model.NewPausePeriod("2025-01-08", "2025-01-12", "休暇")
+						require.NoError(t, err)
+						return mo.Ok([]model.PausePeriod{period})
+					},
+				},
+				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.True(t, report.Paused)
+						assert.True(t, report.EvaluationSkipped)
+						return nil
+					}
+				},
+				NewAcquireLock: func(t *testing.T) locker.Acquire {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					}
+				},
+				NewReleaseLock: func(t *testing.T) locker.Release {
+					return func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					}
+				},
+				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						assert.True(t, report.Paused)
+						return nil
+					}
+				},
+				ctx: appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				input: &usecase.AnalyzeInput{
+					Goal: model.GoalTypeRecentWeek,
+				},
+			},
+			mo.Ok(&usecase.AnalyzeOutput{
+				IsGoalAchieved: false,
+				Paused:         true,
+				LockPath:       usecase.LockPathPrimary,
+			}),
+		},
+		{
+			"return stored result when report already exists",
+			args{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							t.Fatal("must not fetch entries")
+							return mo.Ok([]*model.Entry{})
+						},
+					}
+				},
+				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						t.Fatal("must not notify")
+						return nil
+					}
+				},
+				NewAcquireLock: func(t *testing.T) locker.Acquire {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					}
+				},
+				NewReleaseLock: func(t *testing.T) locker.Release {
+					return func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					}
+				},
+				NewFindAnalysisReport: func(t *testing.T) persister.FindAnalysisReport {
+					return func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+						assert.Equal(t, model.GoalTypeRecentWeek, goal)
+						assert.Equal(t, time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC), now)
+						return mo.Ok(mo.Some(&model.AnalysisReport{
+							Goal:           model.GoalTypeRecentWeek,
+							GeneratedAt:    time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+							IsGoalAchieved: true,
+							EarnedPoints:   3,
+						}))
+					}
+				},
+				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						t.Fatal("must not persist")
+						return nil
+					}
+				},
+				ctx: appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				input: &usecase.AnalyzeInput{
+					Goal: model.GoalTypeRecentWeek,
+				},
+			},
+			mo.Ok(&usecase.AnalyzeOutput{
+				IsGoalAchieved: true,
+				EarnedPoints:   3,
+				LockPath:       usecase.LockPathPrimary,
+			}),
+		},
+		{
+			"analyze again with force even if report already exists",
+			args{
+				NewEntryFetchers: func(t *testing.T) []fetcher.FetchEntries {
+					return []fetcher.FetchEntries{
+						func(ctx context.Context) mo.Result[[]*model.Entry] {
+							return mo.Ok([]*model.Entry{})
+						},
+					}
+				},
+				NewNotifyAnalysisReport: func(t *testing.T) notifier.NotifyAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						return nil
+					}
+				},
+				NewAcquireLock: func(t *testing.T) locker.Acquire {
+					return func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					}
+				},
+				NewReleaseLock: func(t *testing.T) locker.Release {
+					return func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					}
+				},
+				NewFindAnalysisReport: func(t *testing.T) persister.FindAnalysisReport {
+					return func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+						t.Fatal("must not find report with force")
+						return mo.Ok(mo.None[*model.AnalysisReport]())
+					}
+				},
+				NewPersistAnalysisReport: func(t *testing.T) persister.PersistAnalysisReport {
+					return func(ctx context.Context, report *model.AnalysisReport) error {
+						return nil
+					}
+				},
+				ctx: appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				input: &usecase.AnalyzeInput{
+					Goal:  model.GoalTypeRecentWeek,
+					Force: true,
+				},
+			},
+			mo.Ok(&usecase.AnalyzeOutput{
+				IsGoalAchieved: false,
+				LockPath:       usecase.LockPathPrimary,
 			}),
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			// NOTE: 指定がない場合は保存済みのレポートが存在しないものとする
+			newFindAnalysisReport := tt.args.NewFindAnalysisReport
+			if newFindAnalysisReport == nil {
+				newFindAnalysisReport = func(t *testing.T) persister.FindAnalysisReport {
+					return func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+						return mo.Ok(mo.None[*model.AnalysisReport]())
+					}
+				}
+			}
 			got := NewAnalyze(
-				tt.args.NewLatestEntryFetchers(t),
+				tt.args.NewEntryFetchers(t),
+				tt.args.eligibilityRules,
+				model.PauseModeSkip,
+				tt.args.pausePeriodLoaders,
 				tt.args.NewNotifyAnalysisReport(t),
-				tt.args.NewAcquireLock(t),
-				tt.args.NewReleaseLock(t),
-				tt.args.NewPersistAnalysisReport(t),
+				newTestLocker(tt.args.NewAcquireLock(t), tt.args.NewReleaseLock(t)),
+				newFindAnalysisReport(t),
+				[]persister.AnalysisReportPersister{
+					{Name: "s3", Required: true, Persist: tt.args.NewPersistAnalysisReport(t)},
+				},
 			)(
 				tt.args.ctx, tt.args.input,
 			)
@@ -267,3 +624,196 @@ func TestAnalyze(t *testing.T) {
 		})
 	}
 }
+
+func TestAnalyze_Persisters(t *testing.T) {
+	failPersist := func(ctx context.Context, report *model.AnalysisReport) error {
+		return errors.New("failed to persist")
+	}
+	succeedPersist := func(ctx context.Context, report *model.AnalysisReport) error {
+		return nil
+	}
+	primaryPersisted := false
+	primaryPersist := func(ctx context.Context, report *model.AnalysisReport) error {
+		primaryPersisted = true
+		return nil
+	}
+
+	tests := []struct {
+		name                 string
+		persisters           []persister.AnalysisReportPersister
+		wantErr              bool
+		wantNotified         bool
+		wantPrimaryPersisted bool
+		wantFailed           []string
+	}{
+		{
+			"all persisters succeed",
+			[]persister.AnalysisReportPersister{
+				{Name: "s3", Required: true, Primary: true, Persist: primaryPersist},
+				{Name: "file", Persist: succeedPersist},
+			},
+			false,
+			true,
+			true,
+			nil,
+		},
+		{
+			"required persister fails before notification",
+			[]persister.AnalysisReportPersister{
+				{Name: "s3", Required: true, Primary: true, Persist: failPersist},
+				{Name: "file", Persist: succeedPersist},
+			},
+			true,
+			false,
+			false,
+			nil,
+		},
+		// NOTE: 保存済みのレポートが見つかると再試行で保存されないため、参照される保存先には保存しない
+		{
+			"required secondary persister fails before the primary persister",
+			[]persister.AnalysisReportPersister{
+				{Name: "s3", Required: true, Primary: true, Persist: primaryPersist},
+				{Name: "webhook", Required: true, Persist: failPersist},
+			},
+			true,
+			false,
+			false,
+			nil,
+		},
+		{
+			"best-effort persister failures are surfaced",
+			[]persister.AnalysisReportPersister{
+				{Name: "s3", Required: true, Primary: true, Persist: primaryPersist},
+				{Name: "webhook", Persist: failPersist},
+				{Name: "file", Persist: failPersist},
+			},
+			false,
+			true,
+			true,
+			[]string{"file", "webhook"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			primaryPersisted = false
+			notified := false
+			got := NewAnalyze(
+				[]fetcher.FetchEntries{
+					func(ctx context.Context) mo.Result[[]*model.Entry] {
+						return mo.Ok([]*model.Entry{})
+					},
+				},
+				nil,
+				model.PauseModeSkip,
+				nil,
+				func(ctx context.Context, report *model.AnalysisReport) error {
+					notified = true
+					return nil
+				},
+				newTestLocker(
+					func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					},
+					func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					},
+				),
+				func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+					return mo.Ok(mo.None[*model.AnalysisReport]())
+				},
+				tt.persisters,
+			)(
+				appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)),
+				&usecase.AnalyzeInput{Goal: model.GoalTypeRecentWeek},
+			)
+
+			assert.Equal(t, tt.wantNotified, notified)
+			assert.Equal(t, tt.wantPrimaryPersisted, primaryPersisted)
+			if tt.wantErr {
+				assert.True(t, got.IsError())
+				return
+			}
+			require.False(t, got.IsError())
+			assert.Equal(t, tt.wantFailed, got.MustGet().FailedPersisters)
+		})
+	}
+}
+
+// NOTE: スケジューラーによる再試行を想定し、保存に失敗した実行と再試行を通して 1 度だけ通知されることを確認する
+func TestAnalyze_RetryAfterPersistFailure(t *testing.T) {
+	tests := []struct {
+		name string
+		// 1 回目の実行で失敗させる保存先
+		failOnFirstRun string
+	}{
+		{name: "primary persister fails", failOnFirstRun: "s3"},
+		{name: "required secondary persister fails", failOnFirstRun: "webhook"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := 0
+			var stored mo.Option[*model.AnalysisReport]
+			var delivered []*model.AnalysisReport
+			newPersist := func(name string, persist func(report *model.AnalysisReport)) persister.PersistAnalysisReport {
+				return func(ctx context.Context, report *model.AnalysisReport) error {
+					if run == 1 && name == tt.failOnFirstRun {
+						return errors.New("failed to persist")
+					}
+					persist(report)
+					return nil
+				}
+			}
+			notifyCount := 0
+
+			analyze := NewAnalyze(
+				[]fetcher.FetchEntries{
+					func(ctx context.Context) mo.Result[[]*model.Entry] {
+						return mo.Ok([]*model.Entry{})
+					},
+				},
+				nil,
+				model.PauseModeSkip,
+				nil,
+				func(ctx context.Context, report *model.AnalysisReport) error {
+					notifyCount++
+					return nil
+				},
+				newTestLocker(
+					func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					},
+					func(ctx context.Context, lease *locker.Lease) error {
+						return nil
+					},
+				),
+				func(ctx context.Context, goal model.GoalType, now time.Time) mo.Result[mo.Option[*model.AnalysisReport]] {
+					return mo.Ok(stored)
+				},
+				[]persister.AnalysisReportPersister{
+					{Name: "s3", Required: true, Primary: true, Persist: newPersist("s3", func(report *model.AnalysisReport) {
+						stored = mo.Some(report)
+					})},
+					{Name: "webhook", Required: true, Persist: newPersist("webhook", func(report *model.AnalysisReport) {
+						delivered = append(delivered, report)
+					})},
+				},
+			)
+			ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC))
+			in := &usecase.AnalyzeInput{Goal: model.GoalTypeRecentWeek}
+
+			run++
+			assert.True(t, analyze(ctx, in).IsError())
+			assert.True(t, stored.IsAbsent())
+			assert.Equal(t, 0, notifyCount)
+			run++
+			assert.False(t, analyze(ctx, in).IsError())
+			// NOTE: 全ての必須の保存先に保存できた後は、再実行しても通知されない
+			run++
+			assert.False(t, analyze(ctx, in).IsError())
+
+			assert.Equal(t, 1, notifyCount)
+			assert.NotEmpty(t, delivered)
+			assert.Same(t, stored.MustGet(), delivered[len(delivered)-1])
+		})
+	}
+}
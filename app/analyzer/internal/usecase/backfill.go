@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+func NewBackfill(entrySources []fetcher.EntrySource, eligibilityRules *model.EligibilityRules, pauseMode model.PauseMode, pausePeriodLoaders []pause.LoadPausePeriods, persisters []persister.AnalysisReportPersister) usecase.Backfill {
+	return func(ctx context.Context, in *usecase.BackfillInput) mo.Result[*usecase.BackfillOutput] {
+		return backfill(ctx, in, entrySources, eligibilityRules, pauseMode, pausePeriodLoaders, persisters)
+	}
+}
+
+// backfill はエントリを一度だけ取得し、各日の終わりに実行した場合の分析を再現してレポートを保存する。
+// NOTE: レポートは目標と日付から決まるキーに保存されるため、同じ期間で再実行しても同じキーのレポートが上書きされる。
+// 過去のレポートの再構築が目的のため、通知とロックは行わない
+func backfill(ctx context.Context, in *usecase.BackfillInput, entrySources []fetcher.EntrySource, eligibilityRules *model.EligibilityRules, pauseMode model.PauseMode, pausePeriodLoaders []pause.LoadPausePeriods, persisters []persister.AnalysisReportPersister) mo.Result[*usecase.BackfillOutput] {
+	if in.To.Before(in.From) {
+		return mo.Err[*usecase.BackfillOutput](errors.New("to must not be before from"))
+	}
+
+	entriesBySource, err := fetchAllEntries(ctx, entrySources).Get()
+	if err != nil {
+		return mo.Err[*usecase.BackfillOutput](err)
+	}
+	incompleteSources := lo.FilterMap(entrySources, func(source fetcher.EntrySource, _ int) (string, bool) {
+		return source.Name, !source.Complete
+	})
+	completeSince, known := findCompleteSince(entrySources, entriesBySource)
+	entries := lo.Flatten(entriesBySource)
+	out := &usecase.BackfillOutput{}
+	if len(incompleteSources) > 0 {
+		out.IncompleteSources = incompleteSources
+		slog.Warn("some sources cannot provide full history, skip days whose goal period starts before the oldest entry they returned",
+			slog.Any("sources", incompleteSources),
+			slog.Time("complete_since", completeSince),
+			slog.Bool("complete_since_known", known),
+		)
+	}
+
+	pauseSetting := &model.PauseSetting{
+		Periods: loadPausePeriods(ctx, pausePeriodLoaders),
+		Mode:    pauseMode,
+	}
+
+	for day := in.From; !day.After(in.To); day = date.AddDays(day, 1) {
+		now := date.EndOfDay(day).Truncate(time.Second)
+		// NOTE: 目標の期間の一部でもエントリが欠けている可能性があれば、達成状況が正しく再現できないため保存しない
+		if !known || model.GoalPeriodStart(now, in.Goal).Before(completeSince) {
+			out.SkippedDays++
+			continue
+		}
+		// NOTE: その日の時点で公開されていなかったエントリは分析対象外とする
+		published := lo.Filter(entries, func(entry *model.Entry, _ int) bool {
+			return !entry.PublishedAt.After(now)
+		})
+
+		report := model.Analyze(published, now, in.Goal, in.TargetPoints, eligibilityRules, pauseSetting)
+		if _, err := persistAnalysisReport(appctx.SetNow(ctx, now), persisters, report); err != nil {
+			return mo.Err[*usecase.BackfillOutput](fmt.Errorf("failed to persist analysis report of %s: %w", day.Format(time.DateOnly), err))
+		}
+		out.Days++
+	}
+
+	return mo.Ok(out)
+}
+
+// fetchAllEntries は全てのフェッチ元からエントリを取得し、フェッチ元と同じ順序で返す。
+// NOTE: 定期実行と異なり、一部のフェッチ元のエントリが欠けたレポートで過去のレポートを上書きしないよう、1 つでも失敗したらエラーとする
+func fetchAllEntries(ctx context.Context, entrySources []fetcher.EntrySource) mo.Result[[][]*model.Entry] {
+	results := make([]mo.Result[[]*model.Entry], len(entrySources))
+	var wg sync.WaitGroup
+	for i, source := range entrySources {
+		wg.Go(func() {
+			results[i] = source.Fetch(ctx)
+		})
+	}
+	wg.Wait()
+
+	entries := make([][]*model.Entry, len(entrySources))
+	var errs []error
+	for i, result := range results {
+		fetched, err := result.Get()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("failed to fetch entries from %s: %w", entrySources[i].Name, err))
+			continue
+		}
+		entries[i] = fetched
+	}
+	if len(errs) > 0 {
+		return mo.Err[[][]*model.Entry](errors.Join(errs...))
+	}
+	return mo.Ok(entries)
+}
+
+// findCompleteSince はエントリが欠けていないと判断できる最も古い日時を返す。
+// 過去の全てのエントリを返さないフェッチ元は、返したエントリのうち最も古いものより前のエントリが欠けている可能性がある。
+// NOTE: そのようなフェッチ元がエントリを 1 件も返さなかった場合はいつから欠けていないか判断できないため、known を false とする
+func findCompleteSince(entrySources []fetcher.EntrySource, entries [][]*model.Entry) (since time.Time, known bool) {
+	for i, source := range entrySources {
+		if source.Complete {
+			continue
+		}
+		if len(entries[i]) == 0 {
+			return time.Time{}, false
+		}
+		oldest := lo.MinBy(entries[i], func(a, b *model.Entry) bool {
+			return a.PublishedAt.Before(b.PublishedAt)
+		}).PublishedAt
+		if oldest.After(since) {
+			since = oldest
+		}
+	}
+	return since, true
+}
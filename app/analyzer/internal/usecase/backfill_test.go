@@ -0,0 +1,180 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfill(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	old := &model.Entry{
+		Title:       "Goを学ぶ",
+		PublishedAt: time.Date(2024, 12, 20, 12, 0, 0, 0, jst),
+	}
+	recent := &model.Entry{
+		Title:       "Rustを学ぶ",
+		PublishedAt: time.Date(2025, 1, 2, 12, 0, 0, 0, jst),
+	}
+
+	fetchCount := 0
+	entrySources := []fetcher.EntrySource{{
+		Name: "hatena",
+		Fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+			fetchCount++
+			return mo.Ok([]*model.Entry{old, recent})
+		},
+		Complete: true,
+	}}
+
+	type persisted struct {
+		now    time.Time
+		report *model.AnalysisReport
+	}
+	var reports []persisted
+	persist := func(ctx context.Context, report *model.AnalysisReport) error {
+		now, ok := appctx.GetNow(ctx)
+		require.True(t, ok)
+		reports = append(reports, persisted{now: now, report: report})
+		return nil
+	}
+
+	got := NewBackfill(entrySources, nil, model.PauseModeSkip, nil, []persister.AnalysisReportPersister{{Name: "s3", Required: true, Persist: persist}})(context.Background(), &usecase.BackfillInput{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, jst),
+		To:   time.Date(2025, 1, 3, 0, 0, 0, 0, jst),
+		Goal: model.GoalTypeRecentWeek,
+	})
+	require.Equal(t, mo.Ok(&usecase.BackfillOutput{Days: 3}), got)
+	assert.Equal(t, 1, fetchCount)
+
+	require.Len(t, reports, 3)
+	assert.Equal(t, time.Date(2025, 1, 1, 23, 59, 59, 0, jst), reports[0].now)
+	assert.Equal(t, time.Date(2025, 1, 3, 23, 59, 59, 0, jst), reports[2].now)
+
+	// NOTE: 当日時点で未公開のエントリは最新エントリとして扱わない
+	assert.Equal(t, old, reports[0].report.LatestEntry.MustGet())
+	assert.False(t, reports[0].report.IsGoalAchieved)
+	assert.Equal(t, recent, reports[1].report.LatestEntry.MustGet())
+	assert.True(t, reports[1].report.IsGoalAchieved)
+	assert.True(t, reports[2].report.IsGoalAchieved)
+}
+
+// NOTE: 過去の全てのエントリを返さないフェッチ元が返した最も古いエントリより前に目標の期間が始まる日は、エントリが欠けている可能性があるため保存しない
+func TestBackfill_IncompleteSource(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	markdown := fetcher.EntrySource{
+		Name: "markdown",
+		Fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+			return mo.Ok([]*model.Entry{{Title: "Goを学ぶ", PublishedAt: time.Date(2024, 12, 1, 12, 0, 0, 0, jst)}})
+		},
+		Complete: true,
+	}
+
+	tests := []struct {
+		name          string
+		zennEntries   []*model.Entry
+		want          *usecase.BackfillOutput
+		wantPersisted []time.Time
+	}{
+		{
+			name: "skip days whose goal period starts before the oldest entry",
+			zennEntries: []*model.Entry{
+				{Title: "Rustを学ぶ", PublishedAt: time.Date(2024, 12, 25, 12, 0, 0, 0, jst)},
+				{Title: "Zigを学ぶ", PublishedAt: time.Date(2024, 12, 20, 12, 0, 0, 0, jst)},
+			},
+			// NOTE: 12/27 は日の終わりが最も古いエントリより後だが、目標の期間が 12/20 00:00 から始まり最も古いエントリをまたぐため保存しない
+			want: &usecase.BackfillOutput{Days: 1, SkippedDays: 2, IncompleteSources: []string{"zenn"}},
+			wantPersisted: []time.Time{
+				time.Date(2024, 12, 28, 23, 59, 59, 0, jst),
+			},
+		},
+		{
+			name:        "skip all days when the incomplete source returns no entries",
+			zennEntries: nil,
+			want:        &usecase.BackfillOutput{Days: 0, SkippedDays: 3, IncompleteSources: []string{"zenn"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			zenn := fetcher.EntrySource{
+				Name: "zenn",
+				Fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+					return mo.Ok(tt.zennEntries)
+				},
+			}
+
+			var persistedAt []time.Time
+			persist := func(ctx context.Context, report *model.AnalysisReport) error {
+				now, ok := appctx.GetNow(ctx)
+				require.True(t, ok)
+				persistedAt = append(persistedAt, now)
+				return nil
+			}
+
+			got := NewBackfill([]fetcher.EntrySource{markdown, zenn}, nil, model.PauseModeSkip, nil, []persister.AnalysisReportPersister{{Name: "s3", Required: true, Persist: persist}})(context.Background(), &usecase.BackfillInput{
+				From: time.Date(2024, 12, 26, 0, 0, 0, 0, jst),
+				To:   time.Date(2024, 12, 28, 0, 0, 0, 0, jst),
+				Goal: model.GoalTypeRecentWeek,
+			})
+			require.Equal(t, mo.Ok(tt.want), got)
+			assert.Equal(t, tt.wantPersisted, persistedAt)
+		})
+	}
+}
+
+// NOTE: 一部のフェッチ元のエントリが欠けたレポートで過去のレポートを上書きしないよう、1 つでも取得に失敗したら保存しない
+func TestBackfill_FetchError(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	entrySources := []fetcher.EntrySource{
+		{
+			Name: "markdown",
+			Fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+				return mo.Ok([]*model.Entry{{Title: "Goを学ぶ", PublishedAt: time.Date(2024, 12, 1, 12, 0, 0, 0, jst)}})
+			},
+			Complete: true,
+		},
+		{
+			Name: "hatena",
+			Fetch: func(ctx context.Context) mo.Result[[]*model.Entry] {
+				return mo.Err[[]*model.Entry](errors.New("service unavailable"))
+			},
+			Complete: true,
+		},
+	}
+
+	persistCount := 0
+	persist := func(ctx context.Context, report *model.AnalysisReport) error {
+		persistCount++
+		return nil
+	}
+
+	got := NewBackfill(entrySources, nil, model.PauseModeSkip, nil, []persister.AnalysisReportPersister{{Name: "s3", Required: true, Persist: persist}})(context.Background(), &usecase.BackfillInput{
+		From: time.Date(2025, 1, 1, 0, 0, 0, 0, jst),
+		To:   time.Date(2025, 1, 3, 0, 0, 0, 0, jst),
+		Goal: model.GoalTypeRecentWeek,
+	})
+	require.True(t, got.IsError())
+	assert.ErrorContains(t, got.Error(), "hatena")
+	assert.Equal(t, 0, persistCount)
+}
+
+func TestBackfill_InvalidRange(t *testing.T) {
+	got := NewBackfill(nil, nil, model.PauseModeSkip, nil, nil)(context.Background(), &usecase.BackfillInput{
+		From: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+		Goal: model.GoalTypeRecentWeek,
+	})
+	assert.True(t, got.IsError())
+}
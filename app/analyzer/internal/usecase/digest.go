@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/samber/mo/result"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/notifier"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+func NewDigest(entryFetchers []fetcher.FetchEntries, notifyDigestReport notifier.NotifyDigestReport, lock locker.Locker, persistDigestReport persister.PersistDigestReport) usecase.Digest {
+	return func(ctx context.Context, in *usecase.DigestInput) mo.Result[*usecase.DigestOutput] {
+		return digest(ctx, in, entryFetchers, notifyDigestReport, lock, persistDigestReport)
+	}
+}
+
+const (
+	lockIDPrefixDigest = "usecase:digest"
+)
+
+func digest(ctx context.Context, in *usecase.DigestInput, entryFetchers []fetcher.FetchEntries, notifyDigestReport notifier.NotifyDigestReport, lock locker.Locker, persistDigestReport persister.PersistDigestReport) mo.Result[*usecase.DigestOutput] {
+	now := appctx.GetNowOr(ctx, time.Now())
+
+	// NOTE: 期間ごとに 1 日 1 回だけ通知するため、期間の種類もロック ID に含める
+	lockID := fmt.Sprintf("%s:%s:%s", lockIDPrefixDigest, in.Period, now.Format(time.DateOnly))
+	ctx, releaseLock, lockPath, err := acquireLock(ctx, lock, lockID)
+	if err != nil {
+		return mo.Err[*usecase.DigestOutput](err)
+	}
+	defer releaseLock()
+
+	return result.Pipe4(
+		fetchEntries(ctx, entryFetchers),
+		result.Map(func(fetched *fetchedEntries) *model.DigestReport {
+			return model.BuildDigestReport(fetched.entries, now, in.Period)
+		}),
+		result.Map(func(report *model.DigestReport) *model.DigestReport {
+			if err := persistDigestReport(ctx, report); err != nil {
+				slog.Warn("failed to persist digest report", slog.String("error", err.Error()))
+			}
+			return report
+		}),
+		result.Map(func(report *model.DigestReport) *model.DigestReport {
+			if err := notifyDigestReport(ctx, report); err != nil {
+				slog.Warn("failed to notify digest report", slog.String("error", err.Error()))
+			}
+			return report
+		}),
+		result.Map(func(report *model.DigestReport) *usecase.DigestOutput {
+			return &usecase.DigestOutput{
+				Entries:         report.Current.Entries,
+				PreviousEntries: report.Previous.Entries,
+				LockPath:        lockPath,
+			}
+		}),
+	)
+}
@@ -0,0 +1,80 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/fetcher"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDigest(t *testing.T) {
+	ctx := appctx.SetNow(context.Background(), time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC))
+	entryFetchers := []fetcher.FetchEntries{
+		func(ctx context.Context) mo.Result[[]*model.Entry] {
+			return mo.Ok([]*model.Entry{
+				{
+					Title:       "Rustを学ぶ",
+					PublishedAt: time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformZenn(),
+				},
+				{
+					Title:       "Goを学ぶ",
+					PublishedAt: time.Date(2025, 1, 5, 0, 0, 0, 0, time.UTC),
+					Platform:    model.EntryPlatformZenn(),
+				},
+			})
+		},
+		func(ctx context.Context) mo.Result[[]*model.Entry] {
+			return mo.Err[[]*model.Entry](assert.AnError)
+		},
+	}
+
+	var notified, persisted *model.DigestReport
+	got := NewDigest(
+		entryFetchers,
+		func(ctx context.Context, report *model.DigestReport) error {
+			notified = report
+			return nil
+		},
+		newTestLocker(
+			func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+				assert.Equal(t, "usecase:digest:week:2025-01-15", lockID)
+				return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+			},
+			func(ctx context.Context, lease *locker.Lease) error {
+				return nil
+			},
+		),
+		func(ctx context.Context, report *model.DigestReport) error {
+			persisted = report
+			return assert.AnError
+		},
+	)(ctx, &usecase.DigestInput{Period: model.DigestPeriodWeek})
+
+	require.Equal(t, mo.Ok(&usecase.DigestOutput{Entries: 1, PreviousEntries: 1, LockPath: usecase.LockPathPrimary}), got)
+	require.NotNil(t, persisted)
+	assert.Same(t, persisted, notified, "notify even if persist failed")
+}
+
+func TestDigest_LockAlreadyAcquired(t *testing.T) {
+	got := NewDigest(
+		nil,
+		nil,
+		newTestLocker(
+			func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+				return mo.Ok(mo.None[*locker.Lease]())
+			},
+			nil,
+		),
+		nil,
+	)(context.Background(), &usecase.DigestInput{Period: model.DigestPeriodWeek})
+	assert.True(t, got.IsError())
+}
@@ -0,0 +1,31 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+func NewCompactExport(compact persister.CompactAnalysisReportExports) usecase.CompactExport {
+	return func(ctx context.Context, in *usecase.CompactExportInput) mo.Result[*usecase.CompactExportOutput] {
+		return compactExport(ctx, in, compact)
+	}
+}
+
+func compactExport(ctx context.Context, in *usecase.CompactExportInput, compact persister.CompactAnalysisReportExports) mo.Result[*usecase.CompactExportOutput] {
+	if in.To.Before(in.From) {
+		return mo.Err[*usecase.CompactExportOutput](errors.New("to must not be before from"))
+	}
+
+	compacted, err := compact(ctx, in.From, in.To).Get()
+	if err != nil {
+		return mo.Err[*usecase.CompactExportOutput](err)
+	}
+	return mo.Ok(&usecase.CompactExportOutput{
+		Key:  compacted.Key,
+		Rows: compacted.Rows,
+	})
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompactExport(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+
+	var gotFrom, gotTo time.Time
+	compact := func(ctx context.Context, from, to time.Time) mo.Result[*persister.CompactedExport] {
+		gotFrom, gotTo = from, to
+		return mo.Ok(&persister.CompactedExport{Key: "export/analysis_report_compacted/2025-01-01_2025-01-31.parquet", Rows: 62})
+	}
+
+	got := NewCompactExport(compact)(context.Background(), &usecase.CompactExportInput{From: from, To: to})
+	assert.Equal(t, mo.Ok(&usecase.CompactExportOutput{Key: "export/analysis_report_compacted/2025-01-01_2025-01-31.parquet", Rows: 62}), got)
+	assert.Equal(t, from, gotFrom)
+	assert.Equal(t, to, gotTo)
+}
+
+func TestCompactExport_InvalidRange(t *testing.T) {
+	got := NewCompactExport(nil)(context.Background(), &usecase.CompactExportInput{
+		From: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.True(t, got.IsError())
+}
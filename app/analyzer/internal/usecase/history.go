@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+func NewHistory(listAnalysisReports persister.ListAnalysisReports) usecase.History {
+	return func(ctx context.Context, in *usecase.HistoryInput) mo.Result[*usecase.HistoryOutput] {
+		return history(ctx, in, listAnalysisReports)
+	}
+}
+
+func history(ctx context.Context, in *usecase.HistoryInput, listAnalysisReports persister.ListAnalysisReports) mo.Result[*usecase.HistoryOutput] {
+	if in.To.Before(in.From) {
+		return mo.Err[*usecase.HistoryOutput](errors.New("to must not be before from"))
+	}
+
+	reports, err := listAnalysisReports(ctx, in.Goal, in.From, in.To).Get()
+	if err != nil {
+		return mo.Err[*usecase.HistoryOutput](err)
+	}
+	return mo.Ok(&usecase.HistoryOutput{Reports: reports})
+}
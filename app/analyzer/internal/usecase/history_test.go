@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistory(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 31, 0, 0, 0, 0, time.UTC)
+	reports := []*model.AnalysisReport{
+		{Goal: model.GoalTypeRecentWeek, GeneratedAt: from, IsGoalAchieved: true},
+	}
+
+	list := func(ctx context.Context, goal model.GoalType, gotFrom, gotTo time.Time) mo.Result[[]*model.AnalysisReport] {
+		assert.Equal(t, model.GoalTypeRecentWeek, goal)
+		assert.Equal(t, from, gotFrom)
+		assert.Equal(t, to, gotTo)
+		return mo.Ok(reports)
+	}
+
+	got := NewHistory(list)(context.Background(), &usecase.HistoryInput{Goal: model.GoalTypeRecentWeek, From: from, To: to})
+	assert.Equal(t, mo.Ok(&usecase.HistoryOutput{Reports: reports}), got)
+}
+
+func TestHistory_InvalidRange(t *testing.T) {
+	got := NewHistory(nil)(context.Background(), &usecase.HistoryInput{
+		Goal: model.GoalTypeRecentWeek,
+		From: time.Date(2025, 1, 3, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+	})
+	assert.True(t, got.IsError())
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+var (
+	errLockAlreadyAcquired = errors.New("lock already acquired")
+	errLockLost            = errors.New("lock lost")
+)
+
+const (
+	// lockPathFailed はロックを取得できずに実行を失敗させたことをメトリクスに記録する。
+	lockPathFailed = "failed"
+	// lockPathHeld は他の実行がロックを取得済みだったため実行しなかったことをメトリクスに記録する。
+	lockPathHeld = "held"
+)
+
+// NOTE: 他の実行がロックを取得済みだった場合は、primary と fallback のどちらで判明したかによらず lock.path を held として記録する。
+// ロックサービスの障害は lock.path が primary と held 以外の割合で確認する
+var counterLockAcquisition = sync.OnceValue(func() metric.Int64Counter {
+	counter, err := meter.Int64Counter(
+		"lock.acquisition",
+		metric.WithDescription("The number of lock acquisitions by the path taken"),
+	)
+	if err != nil {
+		slog.Error("failed to construct lock acquisition counter", slog.String("error", err.Error()))
+	}
+	return counter
+})
+
+// acquireLock はロックを取得し、解放するまでバックグラウンドで TTL の 1/3 ごとに延長する。
+// 返す ctx は延長できずにロックを失った場合に errLockLost でキャンセルされる。
+// ロックの取得でエラーになった場合は lock.FailurePolicy に従う。
+// NOTE: S3 の待機やリトライで実行が TTL より長くなっても、他の実行と重複しないようにする
+func acquireLock(ctx context.Context, lock locker.Locker, lockID string) (context.Context, func(), usecase.LockPath, error) {
+	lease, err := acquireLease(ctx, lock, lockID)
+	if err == nil {
+		recordLockAcquisition(ctx, lock.FailurePolicy, string(usecase.LockPathPrimary))
+		ctx, release := holdLease(ctx, lock, lease)
+		return ctx, release, usecase.LockPathPrimary, nil
+	}
+	if errors.Is(err, errLockAlreadyAcquired) {
+		recordLockAcquisition(ctx, lock.FailurePolicy, lockPathHeld)
+		return nil, nil, "", err
+	}
+
+	slog.Warn("failed to acquire lock", slog.String("lock_id", lockID), slog.String("failure_policy", string(lock.FailurePolicy)), slog.String("error", err.Error()))
+	switch lock.FailurePolicy {
+	case locker.FailurePolicyProceed:
+		recordLockAcquisition(ctx, lock.FailurePolicy, string(usecase.LockPathUnlocked))
+		return ctx, func() {}, usecase.LockPathUnlocked, nil
+	case locker.FailurePolicyFallback:
+		fallbackLease, fallbackErr := acquireLease(ctx, *lock.Fallback, lockID)
+		if errors.Is(fallbackErr, errLockAlreadyAcquired) {
+			recordLockAcquisition(ctx, lock.FailurePolicy, lockPathHeld)
+			return nil, nil, "", fallbackErr
+		}
+		if fallbackErr != nil {
+			recordLockAcquisition(ctx, lock.FailurePolicy, lockPathFailed)
+			return nil, nil, "", errors.Join(err, fallbackErr)
+		}
+		recordLockAcquisition(ctx, lock.FailurePolicy, string(usecase.LockPathFallback))
+		ctx, release := holdLease(ctx, *lock.Fallback, fallbackLease)
+		return ctx, release, usecase.LockPathFallback, nil
+	default:
+		recordLockAcquisition(ctx, lock.FailurePolicy, lockPathFailed)
+		return nil, nil, "", err
+	}
+}
+
+func acquireLease(ctx context.Context, lock locker.Locker, lockID string) (*locker.Lease, error) {
+	found, err := lock.Acquire(ctx, lockID, lock.TTL).Get()
+	if err != nil {
+		return nil, err
+	}
+	lease, ok := found.Get()
+	if !ok {
+		return nil, errLockAlreadyAcquired
+	}
+	return lease, nil
+}
+
+// holdLease は release が呼ばれるまで lease を延長し、呼ばれたら解放する。
+func holdLease(ctx context.Context, lock locker.Locker, lease *locker.Lease) (context.Context, func()) {
+	lockCtx, cancel := context.WithCancelCause(ctx)
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		heartbeat(lockCtx, lock.Renew, lease, done, cancel)
+	})
+
+	release := func() {
+		close(done)
+		wg.Wait()
+		cancel(nil)
+		// NOTE: 呼び出し元の ctx がキャンセルされていても解放する
+		if err := lock.Release(context.WithoutCancel(ctx), lease); err != nil {
+			slog.Warn("failed to release lock", slog.String("lock_id", lease.LockID), slog.String("error", err.Error()))
+		}
+	}
+	return lockCtx, release
+}
+
+func recordLockAcquisition(ctx context.Context, policy locker.FailurePolicy, path string) {
+	counterLockAcquisition().Add(ctx, 1, metric.WithAttributes(
+		attribute.String("lock.failure_policy", string(policy)),
+		attribute.String("lock.path", path),
+	))
+}
+
+// heartbeat は done が閉じられるまでロックを延長する。
+// NOTE: 延長の失敗は一時的なものとみなして次の延長で再試行し、所有者でなくなった場合のみキャンセルする
+func heartbeat(ctx context.Context, renew locker.Renew, lease *locker.Lease, done <-chan struct{}, cancel context.CancelCauseFunc) {
+	ticker := time.NewTicker(lease.TTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			renewed, err := renew(ctx, lease).Get()
+			if err != nil {
+				slog.Warn("failed to renew lock", slog.String("lock_id", lease.LockID), slog.String("error", err.Error()))
+				continue
+			}
+			if !renewed {
+				slog.Error("lock lost", slog.String("lock_id", lease.LockID))
+				cancel(errLockLost)
+				return
+			}
+		}
+	}
+}
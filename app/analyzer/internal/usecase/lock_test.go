@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/locker"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+)
+
+// newTestLocker は延長が常に成功するロックを返す。
+func newTestLocker(acquire locker.Acquire, release locker.Release) locker.Locker {
+	return locker.Locker{
+		Acquire: acquire,
+		Renew: func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+			return mo.Ok(true)
+		},
+		Release: release,
+		TTL:     time.Minute,
+	}
+}
+
+func TestAcquireLock(t *testing.T) {
+	acquire := func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+		return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+	}
+
+	t.Run("renew until released", func(t *testing.T) {
+		var renewed, released atomic.Int32
+		lock := locker.Locker{
+			Acquire: acquire,
+			Renew: func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+				assert.Equal(t, "token", lease.Token)
+				renewed.Add(1)
+				return mo.Ok(true)
+			},
+			Release: func(ctx context.Context, lease *locker.Lease) error {
+				assert.NoError(t, ctx.Err(), "release with uncanceled context")
+				released.Add(1)
+				return nil
+			},
+			TTL: 30 * time.Millisecond,
+		}
+
+		ctx, release, _, err := acquireLock(context.Background(), lock, "usecase:analyze:2025-01-10")
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool { return renewed.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		assert.NoError(t, ctx.Err())
+
+		release()
+		assert.Equal(t, int32(1), released.Load())
+		count := renewed.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, count, renewed.Load(), "stop renewing after release")
+	})
+
+	t.Run("cancel context when lock is lost", func(t *testing.T) {
+		var renewed atomic.Int32
+		lock := locker.Locker{
+			Acquire: acquire,
+			Renew: func(ctx context.Context, lease *locker.Lease) mo.Result[bool] {
+				// NOTE: 延長の失敗は再試行し、所有者でなくなった場合のみキャンセルする
+				if renewed.Add(1) == 1 {
+					return mo.Err[bool](errors.New("temporary error"))
+				}
+				return mo.Ok(false)
+			},
+			Release: func(ctx context.Context, lease *locker.Lease) error {
+				return nil
+			},
+			TTL: 30 * time.Millisecond,
+		}
+
+		ctx, release, _, err := acquireLock(context.Background(), lock, "usecase:analyze:2025-01-10")
+		require.NoError(t, err)
+		defer release()
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(time.Second):
+			require.Fail(t, "context is not canceled")
+		}
+		assert.ErrorIs(t, context.Cause(ctx), errLockLost)
+		assert.Equal(t, int32(2), renewed.Load())
+	})
+
+	t.Run("already acquired", func(t *testing.T) {
+		lock := locker.Locker{
+			Acquire: func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+				return mo.Ok(mo.None[*locker.Lease]())
+			},
+			TTL: time.Minute,
+		}
+
+		_, _, _, err := acquireLock(context.Background(), lock, "usecase:analyze:2025-01-10")
+		assert.ErrorIs(t, err, errLockAlreadyAcquired)
+	})
+}
+
+// collectLockAcquisitions は lock.acquisition の累計を lock.path ごとに合算して返す。
+func collectLockAcquisitions(t *testing.T, reader *sdkmetric.ManualReader) map[string]int64 {
+	t.Helper()
+	var rm metricdata.ResourceMetrics
+	require.NoError(t, reader.Collect(context.Background(), &rm))
+
+	counts := map[string]int64{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			sum, ok := m.Data.(metricdata.Sum[int64])
+			if m.Name != "lock.acquisition" || !ok {
+				continue
+			}
+			for _, point := range sum.DataPoints {
+				path, _ := point.Attributes.Value("lock.path")
+				counts[path.AsString()] += point.Value
+			}
+		}
+	}
+	return counts
+}
+
+func TestAcquireLock_FailurePolicy(t *testing.T) {
+	reader := sdkmetric.NewManualReader()
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader))
+	otel.SetMeterProvider(provider)
+	t.Cleanup(func() {
+		_ = provider.Shutdown(context.Background())
+	})
+
+	errUnavailable := errors.New("locker unavailable")
+	unavailable := newTestLocker(
+		func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+			return mo.Err[mo.Option[*locker.Lease]](errUnavailable)
+		},
+		nil,
+	)
+	acquired := newTestLocker(
+		func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+			return mo.Ok(mo.None[*locker.Lease]())
+		},
+		nil,
+	)
+
+	available := newTestLocker(
+		func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+			return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+		},
+		func(ctx context.Context, lease *locker.Lease) error {
+			return nil
+		},
+	)
+
+	tests := []struct {
+		name         string
+		primary      locker.Locker
+		policy       locker.FailurePolicy
+		fallback     *locker.Locker
+		wantPath     usecase.LockPath
+		wantErr      error
+		wantReleased bool
+		// lock.acquisition に記録される lock.path
+		wantRecorded string
+	}{
+		{
+			name:         "acquire primary locker",
+			primary:      available,
+			policy:       locker.FailurePolicyFallback,
+			wantPath:     usecase.LockPathPrimary,
+			wantRecorded: "primary",
+		},
+		{
+			name:         "fail",
+			primary:      unavailable,
+			policy:       locker.FailurePolicyFail,
+			wantErr:      errUnavailable,
+			wantRecorded: "failed",
+		},
+		{
+			name:         "proceed without lock",
+			primary:      unavailable,
+			policy:       locker.FailurePolicyProceed,
+			wantPath:     usecase.LockPathUnlocked,
+			wantRecorded: "unlocked",
+		},
+		{
+			name:         "fall back to secondary locker",
+			primary:      unavailable,
+			policy:       locker.FailurePolicyFallback,
+			wantPath:     usecase.LockPathFallback,
+			wantReleased: true,
+			wantRecorded: "fallback",
+		},
+		{
+			name:         "fallback locker is also unavailable",
+			primary:      unavailable,
+			policy:       locker.FailurePolicyFallback,
+			fallback:     &unavailable,
+			wantErr:      errUnavailable,
+			wantRecorded: "failed",
+		},
+		{
+			name:         "fallback lock is already acquired",
+			primary:      unavailable,
+			policy:       locker.FailurePolicyFallback,
+			fallback:     &acquired,
+			wantErr:      errLockAlreadyAcquired,
+			wantRecorded: "held",
+		},
+		{
+			// NOTE: 他の実行がロックを取得済みの場合はポリシーに関わらず重複実行しない
+			name:         "already acquired regardless of policy",
+			primary:      acquired,
+			policy:       locker.FailurePolicyProceed,
+			wantErr:      errLockAlreadyAcquired,
+			wantRecorded: "held",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			released := false
+			fallback := tt.fallback
+			if fallback == nil {
+				fallback = lo.ToPtr(newTestLocker(
+					func(ctx context.Context, lockID string, ttl time.Duration) mo.Result[mo.Option[*locker.Lease]] {
+						return mo.Ok(mo.Some(&locker.Lease{LockID: lockID, Token: "token", TTL: ttl}))
+					},
+					func(ctx context.Context, lease *locker.Lease) error {
+						released = true
+						return nil
+					},
+				))
+			}
+			lock := tt.primary
+			lock.FailurePolicy = tt.policy
+			lock.Fallback = fallback
+
+			before := collectLockAcquisitions(t, reader)
+			ctx, release, path, err := acquireLock(context.Background(), lock, "usecase:analyze:2025-01-10")
+			after := collectLockAcquisitions(t, reader)
+			recorded := lo.PickBy(after, func(path string, count int64) bool {
+				return count != before[path]
+			})
+			assert.Equal(t, map[string]int64{tt.wantRecorded: before[tt.wantRecorded] + 1}, recorded)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, ctx.Err())
+			assert.Equal(t, tt.wantPath, path)
+
+			release()
+			assert.Equal(t, tt.wantReleased, released)
+		})
+	}
+}
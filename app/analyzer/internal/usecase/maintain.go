@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+// NewMaintain は保存先ごとのメンテナンスを順に実行する。
+func NewMaintain(maintainers []persister.MaintainReports) usecase.Maintain {
+	return func(ctx context.Context, in *usecase.MaintainInput) mo.Result[*usecase.MaintainOutput] {
+		return maintain(ctx, in, maintainers)
+	}
+}
+
+func maintain(ctx context.Context, in *usecase.MaintainInput, maintainers []persister.MaintainReports) mo.Result[*usecase.MaintainOutput] {
+	if in.RetentionDays < 0 {
+		return mo.Err[*usecase.MaintainOutput](errors.New("retention days must not be negative"))
+	}
+
+	now := appctx.GetNowOr(ctx, time.Now())
+	policy := &persister.MaintenancePolicy{
+		Now:     now,
+		Archive: in.Archive,
+		DryRun:  in.DryRun,
+	}
+	// NOTE: 保持期間は JST の日付単位で数え、今日を含めて RetentionDays 日分を残す
+	if in.RetentionDays > 0 {
+		policy.RetentionCutoff = date.BeginningOfDay(date.AddDays(now.In(lo.ToPtr(date.LocationJST())), -(in.RetentionDays - 1)))
+	}
+
+	out := &usecase.MaintainOutput{}
+	for _, maintainReports := range maintainers {
+		result, err := maintainReports(ctx, policy).Get()
+		if err != nil {
+			return mo.Err[*usecase.MaintainOutput](err)
+		}
+		out.Compacted += result.Compacted
+		out.MonthlyFiles += result.MonthlyFiles
+		out.Deleted += result.Deleted
+		out.Archived += result.Archived
+	}
+	return mo.Ok(out)
+}
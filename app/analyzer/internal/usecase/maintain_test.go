@@ -0,0 +1,79 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/appctx"
+	"github.com/ss49919201/keeput/app/analyzer/internal/date"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaintain(t *testing.T) {
+	jst := lo.ToPtr(date.LocationJST())
+	now := time.Date(2025, 3, 10, 8, 0, 0, 0, jst)
+
+	tests := []struct {
+		name       string
+		in         *usecase.MaintainInput
+		wantPolicy *persister.MaintenancePolicy
+		want       mo.Result[*usecase.MaintainOutput]
+	}{
+		{
+			name: "retention days is applied by JST date",
+			in:   &usecase.MaintainInput{RetentionDays: 30, Archive: true},
+			wantPolicy: &persister.MaintenancePolicy{
+				Now:             now,
+				RetentionCutoff: time.Date(2025, 2, 9, 0, 0, 0, 0, jst),
+				Archive:         true,
+			},
+			want: mo.Ok(&usecase.MaintainOutput{Compacted: 2, MonthlyFiles: 2, Archived: 4}),
+		},
+		{
+			name: "no retention when retention days is 0",
+			in:   &usecase.MaintainInput{DryRun: true},
+			wantPolicy: &persister.MaintenancePolicy{
+				Now:    now,
+				DryRun: true,
+			},
+			want: mo.Ok(&usecase.MaintainOutput{Compacted: 2, MonthlyFiles: 2, Archived: 4}),
+		},
+		{
+			name: "negative retention days",
+			in:   &usecase.MaintainInput{RetentionDays: -1},
+			want: mo.Err[*usecase.MaintainOutput](errors.New("retention days must not be negative")),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			maintainReports := func(ctx context.Context, policy *persister.MaintenancePolicy) mo.Result[*persister.MaintenanceResult] {
+				assert.Equal(t, tt.wantPolicy, policy)
+				return mo.Ok(&persister.MaintenanceResult{Compacted: 1, MonthlyFiles: 1, Archived: 2})
+			}
+
+			got := NewMaintain([]persister.MaintainReports{maintainReports, maintainReports})(appctx.SetNow(context.Background(), now), tt.in)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaintain_Error(t *testing.T) {
+	called := false
+	failed := func(ctx context.Context, policy *persister.MaintenancePolicy) mo.Result[*persister.MaintenanceResult] {
+		return mo.Err[*persister.MaintenanceResult](errors.New("failed"))
+	}
+	next := func(ctx context.Context, policy *persister.MaintenancePolicy) mo.Result[*persister.MaintenanceResult] {
+		called = true
+		return mo.Ok(&persister.MaintenanceResult{})
+	}
+
+	got := NewMaintain([]persister.MaintainReports{failed, next})(context.Background(), &usecase.MaintainInput{})
+	assert.True(t, got.IsError())
+	assert.False(t, called)
+}
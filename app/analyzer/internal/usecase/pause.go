@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/pause"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+)
+
+func NewAddPausePeriod(loadPausePeriods pause.LoadPausePeriods, savePausePeriods pause.SavePausePeriods) usecase.AddPausePeriod {
+	return func(ctx context.Context, in *usecase.AddPausePeriodInput) mo.Result[*usecase.PausePeriodsOutput] {
+		return updatePausePeriods(ctx, loadPausePeriods, savePausePeriods, func(periods []model.PausePeriod) ([]model.PausePeriod, error) {
+			if slices.ContainsFunc(periods, func(p model.PausePeriod) bool {
+				return isSamePausePeriod(p, in.Period)
+			}) {
+				return nil, errors.New("pause period already exists")
+			}
+			return append(periods, in.Period), nil
+		})
+	}
+}
+
+func NewRemovePausePeriod(loadPausePeriods pause.LoadPausePeriods, savePausePeriods pause.SavePausePeriods) usecase.RemovePausePeriod {
+	return func(ctx context.Context, in *usecase.RemovePausePeriodInput) mo.Result[*usecase.PausePeriodsOutput] {
+		return updatePausePeriods(ctx, loadPausePeriods, savePausePeriods, func(periods []model.PausePeriod) ([]model.PausePeriod, error) {
+			remaining := lo.Reject(periods, func(p model.PausePeriod, _ int) bool {
+				return isSamePausePeriod(p, model.PausePeriod{Start: in.Start, End: in.End})
+			})
+			if len(remaining) == len(periods) {
+				return nil, errors.New("pause period not found")
+			}
+			return remaining, nil
+		})
+	}
+}
+
+func NewListPausePeriods(loadPausePeriods pause.LoadPausePeriods) usecase.ListPausePeriods {
+	return func(ctx context.Context) mo.Result[*usecase.PausePeriodsOutput] {
+		return mo.Fold(
+			loadPausePeriods(ctx),
+			func(periods []model.PausePeriod) mo.Result[*usecase.PausePeriodsOutput] {
+				return mo.Ok(&usecase.PausePeriodsOutput{Periods: sortPausePeriods(periods)})
+			},
+			func(err error) mo.Result[*usecase.PausePeriodsOutput] {
+				return mo.Err[*usecase.PausePeriodsOutput](err)
+			},
+		)
+	}
+}
+
+func updatePausePeriods(ctx context.Context, loadPausePeriods pause.LoadPausePeriods, savePausePeriods pause.SavePausePeriods, update func([]model.PausePeriod) ([]model.PausePeriod, error)) mo.Result[*usecase.PausePeriodsOutput] {
+	periods, err := loadPausePeriods(ctx).Get()
+	if err != nil {
+		return mo.Err[*usecase.PausePeriodsOutput](err)
+	}
+	updated, err := update(periods)
+	if err != nil {
+		return mo.Err[*usecase.PausePeriodsOutput](err)
+	}
+	updated = sortPausePeriods(updated)
+	if err := savePausePeriods(ctx, updated); err != nil {
+		return mo.Err[*usecase.PausePeriodsOutput](err)
+	}
+	return mo.Ok(&usecase.PausePeriodsOutput{Periods: updated})
+}
+
+func isSamePausePeriod(a, b model.PausePeriod) bool {
+	return a.Start.Equal(b.Start) && a.End.Equal(b.End)
+}
+
+func sortPausePeriods(periods []model.PausePeriod) []model.PausePeriod {
+	return slices.SortedStableFunc(slices.Values(periods), func(a, b model.PausePeriod) int {
+		return cmp.Or(a.Start.Compare(b.Start), a.End.Compare(b.End))
+	})
+}
@@ -0,0 +1,52 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/samber/mo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/usecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPausePeriods(t *testing.T) {
+	mustPausePeriod := func(start, end string) model.PausePeriod {
+		period, err := model.NewPausePeriod(start, end, "")
+		require.NoError(t, err)
+		return period
+	}
+
+	var stored []model.PausePeriod
+	load := func(ctx context.Context) mo.Result[[]model.PausePeriod] {
+		return mo.Ok(stored)
+	}
+	save := func(ctx context.Context, periods []model.PausePeriod) error {
+		stored = periods
+		return nil
+	}
+	ctx := context.Background()
+
+	summer := mustPausePeriod("2025-08-10", "2025-08-16")
+	winter := mustPausePeriod("2024-12-28", "2025-01-03")
+
+	add := NewAddPausePeriod(load, save)
+	require.False(t, add(ctx, &usecase.AddPausePeriodInput{Period: summer}).IsError())
+	got := add(ctx, &usecase.AddPausePeriodInput{Period: winter})
+	require.False(t, got.IsError())
+	assert.Equal(t, []model.PausePeriod{winter, summer}, got.MustGet().Periods)
+
+	assert.True(t, add(ctx, &usecase.AddPausePeriodInput{Period: summer}).IsError(), "duplicated period")
+
+	remove := NewRemovePausePeriod(load, save)
+	got = remove(ctx, &usecase.RemovePausePeriodInput{Start: winter.Start, End: winter.End})
+	require.False(t, got.IsError())
+	assert.Equal(t, []model.PausePeriod{summer}, got.MustGet().Periods)
+
+	assert.True(t, remove(ctx, &usecase.RemovePausePeriodInput{Start: winter.Start, End: winter.End}).IsError(), "not found")
+
+	got = NewListPausePeriods(load)(ctx)
+	require.False(t, got.IsError())
+	assert.Equal(t, []model.PausePeriod{summer}, got.MustGet().Periods)
+}
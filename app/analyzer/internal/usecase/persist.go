@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/samber/lo"
+	"github.com/ss49919201/keeput/app/analyzer/internal/model"
+	"github.com/ss49919201/keeput/app/analyzer/internal/port/persister"
+)
+
+// persistAnalysisReport は全ての保存先にレポートを保存し、保存に失敗した保存先の名前を返す。
+// NOTE: 必須の保存先に保存できなかった場合はエラーを返す。エラーの場合も失敗した必須の保存先を含めて名前を返す。
+// FindAnalysisReport が参照する保存先にレポートがあれば全ての必須の保存先に保存済みとみなせるよう、
+// 参照する保存先には他の保存先への保存が終わり、必須の保存先に全て保存できた場合のみ保存する
+func persistAnalysisReport(ctx context.Context, persisters []persister.AnalysisReportPersister, report *model.AnalysisReport) ([]string, error) {
+	primaries, others := lo.FilterReject(persisters, func(p persister.AnalysisReportPersister, _ int) bool {
+		return p.Primary
+	})
+
+	failed, err := persistConcurrently(ctx, others, report)
+	if err != nil {
+		return failed, err
+	}
+	failedPrimaries, err := persistConcurrently(ctx, primaries, report)
+	failed = append(failed, failedPrimaries...)
+	slices.Sort(failed)
+	return failed, err
+}
+
+// persistConcurrently は保存先に並行してレポートを保存し、保存に失敗した保存先の名前を返す。
+func persistConcurrently(ctx context.Context, persisters []persister.AnalysisReportPersister, report *model.AnalysisReport) ([]string, error) {
+	type persistResult struct {
+		persister persister.AnalysisReportPersister
+		err       error
+	}
+
+	resultCh := make(chan persistResult, len(persisters))
+	for _, p := range persisters {
+		go func() {
+			resultCh <- persistResult{persister: p, err: p.Persist(ctx, report)}
+		}()
+	}
+
+	var failed []string
+	var errs []error
+	for range len(persisters) {
+		result := <-resultCh
+		if result.err == nil {
+			continue
+		}
+		failed = append(failed, result.persister.Name)
+		if result.persister.Required {
+			errs = append(errs, fmt.Errorf("failed to persist analysis report to %s: %w", result.persister.Name, result.err))
+			continue
+		}
+		slog.Warn("failed to persist analysis report", slog.String("persister", result.persister.Name), slog.String("error", result.err.Error()))
+	}
+	slices.Sort(failed)
+	return failed, errors.Join(errs...)
+}
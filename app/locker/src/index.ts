@@ -2,7 +2,7 @@ import { vValidator } from "@hono/valibot-validator";
 import { Hono } from "hono";
 import { HTTPException } from "hono/http-exception";
 import { logger } from "hono/logger";
-import { object, string } from "valibot";
+import { integer, minValue, number, object, pipe, string } from "valibot";
 import { Locker } from "./locker";
 
 type Bindings = {
@@ -10,6 +10,8 @@ type Bindings = {
   API_KEY: string;
 };
 
+const ttlMs = pipe(number(), integer(), minValue(1));
+
 const app = new Hono<{ Bindings: Bindings }>();
 
 // middleware
@@ -40,12 +42,33 @@ const app = new Hono<{ Bindings: Bindings }>();
       "json",
       object({
         lockId: string(),
+        token: string(),
+        ttlMs,
+      })
+    ),
+    async (c) => {
+      const { lockId, token, ttlMs } = c.req.valid("json");
+      const stub = c.env.LOCKER.get(c.env.LOCKER.idFromName(lockId));
+      const result = await stub.acquire(token, ttlMs);
+      return c.json({
+        msg: result ? "ok" : "ng",
+      });
+    }
+  );
+  app.post(
+    "/renew",
+    vValidator(
+      "json",
+      object({
+        lockId: string(),
+        token: string(),
+        ttlMs,
       })
     ),
     async (c) => {
-      const { lockId } = c.req.valid("json");
+      const { lockId, token, ttlMs } = c.req.valid("json");
       const stub = c.env.LOCKER.get(c.env.LOCKER.idFromName(lockId));
-      const result = await stub.acquire();
+      const result = await stub.renew(token, ttlMs);
       return c.json({
         msg: result ? "ok" : "ng",
       });
@@ -57,12 +80,13 @@ const app = new Hono<{ Bindings: Bindings }>();
       "json",
       object({
         lockId: string(),
+        token: string(),
       })
     ),
     async (c) => {
-      const { lockId } = c.req.valid("json");
+      const { lockId, token } = c.req.valid("json");
       const stub = c.env.LOCKER.get(c.env.LOCKER.idFromName(lockId));
-      await stub.release();
+      await stub.release(token);
       return c.json({
         msg: "ok",
       });
@@ -1,24 +1,57 @@
 import { DurableObject } from "cloudflare:workers";
 
-// 10秒間リクエストがない場合、インスタンスが破棄される。
-// この挙動を利用して一定時間後の自動リリースを実現している。
-export class Locker extends DurableObject {
-  #isLocked = false;
+type Lock = {
+  token: string;
+  expiresAt: number;
+};
+
+const lockKey = "lock";
 
+// ロックの所有者と期限をストレージに保存する。
+// 期限を過ぎたロックは解放されたものとみなし、他の所有者が取得できる。
+export class Locker extends DurableObject {
   public constructor(state: DurableObjectState, env: unknown) {
     super(state, env);
   }
 
-  public async acquire(): Promise<boolean> {
-    if (this.#isLocked) {
+  public async acquire(token: string, ttlMs: number): Promise<boolean> {
+    const lock = await this.ctx.storage.get<Lock>(lockKey);
+    if (lock !== undefined && Date.now() < lock.expiresAt) {
       return false;
     }
 
-    this.#isLocked = true;
+    await this.ctx.storage.put<Lock>(lockKey, {
+      token,
+      expiresAt: Date.now() + ttlMs,
+    });
     return true;
   }
 
-  public async release(): Promise<void> {
-    this.#isLocked = false;
+  // 所有者でない場合や期限を過ぎていた場合は延長せずに false を返す。
+  public async renew(token: string, ttlMs: number): Promise<boolean> {
+    const lock = await this.ctx.storage.get<Lock>(lockKey);
+    if (
+      lock === undefined ||
+      lock.token !== token ||
+      lock.expiresAt <= Date.now()
+    ) {
+      return false;
+    }
+
+    await this.ctx.storage.put<Lock>(lockKey, {
+      token,
+      expiresAt: Date.now() + ttlMs,
+    });
+    return true;
+  }
+
+  // 所有者でない場合は何もしない。
+  public async release(token: string): Promise<void> {
+    const lock = await this.ctx.storage.get<Lock>(lockKey);
+    if (lock?.token !== token) {
+      return;
+    }
+
+    await this.ctx.storage.delete(lockKey);
   }
 }
@@ -26,4 +26,5 @@ module "service" {
   env       = "prod"
   scheduler_state = "ENABLED"
   s3_bucket = module.storage.s3_bucket
+  dynamodb_lock_table = module.storage.dynamodb_lock_table
 }
\ No newline at end of file
@@ -9,6 +9,13 @@ variable "s3_bucket" {
   })
 }
 
+variable "dynamodb_lock_table" {
+  type = object({
+    name = string
+    arn  = string
+  })
+}
+
 variable "scheduler_state" {
   type = string # DISABLED | ENABLED
 }
@@ -76,6 +83,16 @@ data "aws_iam_policy_document" "analyzer_lambda_permissions" {
     ]
     resources = [var.s3_bucket.arn]
   }
+
+  statement {
+    effect = "Allow"
+    actions = [
+      "dynamodb:PutItem",
+      "dynamodb:UpdateItem",
+      "dynamodb:DeleteItem"
+    ]
+    resources = [var.dynamodb_lock_table.arn]
+  }
 }
 
 resource "aws_iam_policy" "keeput_analyzer_lambda_policy" {
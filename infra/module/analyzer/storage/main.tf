@@ -40,9 +40,33 @@ resource "aws_s3_bucket_logging" "example" {
   target_prefix = "log/"
 }
 
+# NOTE: analyzer の重複実行を防ぐロック用。解放されなかったロックは expires_at を過ぎると TTL で削除される
+resource "aws_dynamodb_table" "analyzer_lock" {
+  name         = "keeput-lock-${var.env}"
+  billing_mode = "PAY_PER_REQUEST"
+  hash_key     = "lock_id"
+
+  attribute {
+    name = "lock_id"
+    type = "S"
+  }
+
+  ttl {
+    attribute_name = "expires_at"
+    enabled        = true
+  }
+}
+
 output "s3_bucket" {
   value = {
     name = aws_s3_bucket.analyzer.id
     arn  = aws_s3_bucket.analyzer.arn
   }
 }
+
+output "dynamodb_lock_table" {
+  value = {
+    name = aws_dynamodb_table.analyzer_lock.name
+    arn  = aws_dynamodb_table.analyzer_lock.arn
+  }
+}